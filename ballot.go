@@ -0,0 +1,147 @@
+package checkerscore
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+)
+
+/*
+Three-move ballots, as used in tournament English checkers: rather than
+always starting from the standard position, each game (or pair of games
+with colors reversed) begins with the first three moves dictated by an
+opening drawn at random from a deck.
+
+Ballots are written as three moves in standard notation, eg:
+
+	11-15 23-19 8-11
+
+The ACF deck is a subset of the legal three-move openings with the
+unsound ones barred, and its list of openings and their names isn't
+included here.  ThreeMoveBallots returns every legal three-move opening,
+counting openings which transpose into the same position once, named by
+their moves.  ReadBallots loads a specific deck such as the ACF one, one
+ballot per line, optionally preceded by a name, checking that every
+ballot is legal:
+
+	Ballot 1: 11-15 23-19 8-11
+*/
+
+type Ballot struct {
+	Name  string
+	Moves []string // in standard notation, eg "11-15"
+}
+
+// Create a ballot, validating that the moves are legal when played from
+// the standard starting position.  If name is empty, the moves are used.
+func NewBallot(name string, moves ...string) (Ballot, error) {
+
+	if len(moves) == 0 {
+		return Ballot{}, fmt.Errorf("ballot has no moves")
+	}
+	if name == "" {
+		name = strings.Join(moves, " ")
+	}
+	ballot := Ballot{Name: name, Moves: moves}
+	game := PDNGame{Moves: moves}
+	if err := game.Replay(nil); err != nil {
+		return Ballot{}, fmt.Errorf("ballot %v: %v", name, err)
+	}
+	return ballot, nil
+
+}
+
+// The board after the ballot's moves have been played from the standard
+// starting position, and the player to move next.
+func (ballot Ballot) Position() (Board, Player) {
+
+	board := NewStartingBoard()
+	player := BLACK_PLAYER
+	for _, notation := range ballot.Moves {
+		move, err := board.ParseMove(player, notation)
+		if err != nil {
+			panic(fmt.Sprintf("invalid ballot %v: %v", ballot.Name, err))
+		}
+		board = board.ApplyMove(player, move)
+		player = player.Opponent()
+	}
+	return board, player
+
+}
+
+func (ballot Ballot) String() string {
+	return ballot.Name
+}
+
+// Every legal three-move opening from the standard starting position.
+// Of the openings reaching the same position with the moves in a
+// different order, only the first generated is included.
+func ThreeMoveBallots() []Ballot {
+
+	ballots := []Ballot{}
+	reached := map[Board]bool{}
+	var collect func(board Board, player Player, moves []string)
+	collect = func(board Board, player Player, moves []string) {
+		if len(moves) == 3 {
+			if reached[board] {
+				return
+			}
+			reached[board] = true
+			ballot := Ballot{
+				Name:  strings.Join(moves, " "),
+				Moves: append([]string{}, moves...),
+			}
+			ballots = append(ballots, ballot)
+			return
+		}
+		for _, move := range board.LegalMoves(player) {
+			boardPostMove := board.ApplyMove(player, move)
+			collect(boardPostMove, player.Opponent(), append(moves, move.Notation()))
+		}
+	}
+	collect(NewStartingBoard(), BLACK_PLAYER, []string{})
+	return ballots
+
+}
+
+// Read a deck of ballots, one per line.  Blank lines and lines starting
+// with # are ignored.
+func ReadBallots(reader io.Reader) ([]Ballot, error) {
+
+	ballots := []Ballot{}
+	scanner := bufio.NewScanner(reader)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber += 1
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		name := ""
+		if index := strings.Index(line, ":"); index != -1 {
+			name = strings.TrimSpace(line[:index])
+			line = line[index+1:]
+		}
+		ballot, err := NewBallot(name, strings.Fields(line)...)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", lineNumber, err)
+		}
+		ballots = append(ballots, ballot)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return ballots, nil
+
+}
+
+// Pick a ballot from the deck at random.
+func RandomBallot(ballots []Ballot, rng *rand.Rand) Ballot {
+	if len(ballots) == 0 {
+		panic("no ballots to pick from")
+	}
+	return ballots[rng.Intn(len(ballots))]
+}
@@ -0,0 +1,71 @@
+package checkerscore
+
+import (
+	"github.com/couchbaselabs/go.assert"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestThreeMoveBallots(t *testing.T) {
+
+	// 302 three-move sequences, reaching 216 different positions
+	ballots := ThreeMoveBallots()
+	assert.Equals(t, len(ballots), 216)
+
+	positions := map[Board]bool{}
+	for _, ballot := range ballots {
+		_, err := NewBallot(ballot.Name, ballot.Moves...)
+		assert.True(t, err == nil)
+		board, _ := ballot.Position()
+		positions[board] = true
+	}
+	assert.Equals(t, len(positions), 216)
+
+}
+
+func TestBallotPosition(t *testing.T) {
+
+	ballot, err := NewBallot("", "11-15", "24-19", "15x24")
+	assert.True(t, err == nil)
+	assert.Equals(t, ballot.Name, "11-15 24-19 15x24")
+
+	board, player := ballot.Position()
+	assert.Equals(t, player, RED_PLAYER)
+	expectedBoardStr := "" +
+		"|- o - o - o - o|" +
+		"|o - o - o - o -|" +
+		"|- o - o - - - o|" +
+		"|- - - - - - - -|" +
+		"|- - - - - - - -|" +
+		"|x - x - x - o -|" +
+		"|- x - x - x - x|" +
+		"|x - x - x - x -|"
+	assert.Equals(t, board.CompactString(false), expectedBoardStr)
+
+	_, err = NewBallot("", "11-15", "24-19", "8-11")
+	assert.True(t, err != nil)
+
+}
+
+func TestReadBallots(t *testing.T) {
+
+	deck := `
+# a small deck
+Old Fourteenth: 11-15 23-19 8-11
+9-13 21-17 5-9
+`
+	ballots, err := ReadBallots(strings.NewReader(deck))
+	assert.True(t, err == nil)
+	assert.Equals(t, len(ballots), 2)
+	assert.Equals(t, ballots[0].Name, "Old Fourteenth")
+	assert.Equals(t, ballots[1].Name, "9-13 21-17 5-9")
+
+	rng := rand.New(rand.NewSource(1))
+	ballot := RandomBallot(ballots, rng)
+	assert.True(t, ballot.Name == ballots[0].Name || ballot.Name == ballots[1].Name)
+
+	_, err = ReadBallots(strings.NewReader("11-15 23-19 11-15"))
+	assert.True(t, err != nil)
+
+}
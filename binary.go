@@ -0,0 +1,160 @@
+package checkerscore
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+/*
+Compact binary encoding of a Position, which is always
+PositionBinarySize bytes long:
+
+  - a 32-bit mask of the squares with black pieces
+  - a 32-bit mask of the squares with red pieces
+  - a 32-bit mask of the squares with kings
+  - one byte for the player to move (0 for red, 1 for black)
+
+Bit n-1 of each mask corresponds to square n in standard notation, and
+the masks are written big-endian.  Only pieces on the 32 playable squares
+can be encoded, and only positions in English checkers, as the variant
+isn't recorded: decoded positions have a nil Variant.
+*/
+
+const PositionBinarySize = 13
+
+func (position Position) MarshalBinary() ([]byte, error) {
+
+	if position.Variant.rules() != ENGLISH_VARIANT {
+		return nil, fmt.Errorf("cannot encode a position in %v", position.Variant.Name)
+	}
+
+	var blackMask, redMask, kingMask uint32
+	var err error
+	position.Board.applyEachSquare(func(loc Location) {
+		piece := position.Board.pieceAt(loc)
+		if piece == EMPTY {
+			return
+		}
+		square := loc.SquareNumber()
+		if square == 0 {
+			err = fmt.Errorf("cannot encode %v on light square (%d,%d)", piece, loc.row, loc.col)
+			return
+		}
+		bit := uint32(1) << uint(square-1)
+		if piece.OwnedBy(BLACK_PLAYER) {
+			blackMask |= bit
+		} else {
+			redMask |= bit
+		}
+		if piece.IsKing() {
+			kingMask |= bit
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, PositionBinarySize)
+	binary.BigEndian.PutUint32(data[0:4], blackMask)
+	binary.BigEndian.PutUint32(data[4:8], redMask)
+	binary.BigEndian.PutUint32(data[8:12], kingMask)
+	data[12] = byte(position.Player)
+	return data, nil
+
+}
+
+func (position *Position) UnmarshalBinary(data []byte) error {
+
+	if len(data) != PositionBinarySize {
+		return fmt.Errorf("expected %d bytes, got %d", PositionBinarySize, len(data))
+	}
+	blackMask := binary.BigEndian.Uint32(data[0:4])
+	redMask := binary.BigEndian.Uint32(data[4:8])
+	kingMask := binary.BigEndian.Uint32(data[8:12])
+
+	if blackMask&redMask != 0 {
+		return fmt.Errorf("squares occupied by both players: %08x", blackMask&redMask)
+	}
+	if kingMask&^(blackMask|redMask) != 0 {
+		return fmt.Errorf("kings on empty squares: %08x", kingMask&^(blackMask|redMask))
+	}
+
+	decoded := Position{Board: NewEmptyBoard()}
+	switch Player(data[12]) {
+	case RED_PLAYER, BLACK_PLAYER:
+		decoded.Player = Player(data[12])
+	default:
+		return fmt.Errorf("invalid player to move: %d", data[12])
+	}
+
+	for square := 1; square <= 32; square++ {
+		bit := uint32(1) << uint(square-1)
+		var piece Piece
+		switch {
+		case blackMask&bit != 0:
+			piece = BLACK
+		case redMask&bit != 0:
+			piece = RED
+		default:
+			continue
+		}
+		if kingMask&bit != 0 {
+			piece = piece.King()
+		}
+		loc, _ := NewLocationFromSquareNumber(square)
+		decoded.Board[loc.row][loc.col] = piece
+	}
+
+	*position = decoded
+	return nil
+
+}
+
+// Writes a stream of binary encoded positions.  Flush must be called
+// when done.
+type PositionWriter struct {
+	writer *bufio.Writer
+}
+
+func NewPositionWriter(writer io.Writer) *PositionWriter {
+	return &PositionWriter{writer: bufio.NewWriter(writer)}
+}
+
+func (positionWriter *PositionWriter) Write(position Position) error {
+	data, err := position.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	_, err = positionWriter.writer.Write(data)
+	return err
+}
+
+func (positionWriter *PositionWriter) Flush() error {
+	return positionWriter.writer.Flush()
+}
+
+// Reads a stream of binary encoded positions, as written by PositionWriter.
+type PositionReader struct {
+	reader *bufio.Reader
+	buffer []byte
+}
+
+func NewPositionReader(reader io.Reader) *PositionReader {
+	return &PositionReader{
+		reader: bufio.NewReader(reader),
+		buffer: make([]byte, PositionBinarySize),
+	}
+}
+
+// Read the next position, returning io.EOF when there are no more.
+func (positionReader *PositionReader) Read() (Position, error) {
+	position := Position{}
+	_, err := io.ReadFull(positionReader.reader, positionReader.buffer)
+	if err != nil {
+		return position, err
+	}
+	err = position.UnmarshalBinary(positionReader.buffer)
+	return position, err
+}
@@ -0,0 +1,103 @@
+package checkerscore
+
+import (
+	"bytes"
+	"github.com/couchbaselabs/go.assert"
+	"io"
+	"testing"
+)
+
+func TestPositionBinaryRoundTrip(t *testing.T) {
+
+	position := NewStartingPosition()
+	data, err := position.MarshalBinary()
+	assert.True(t, err == nil)
+	assert.Equals(t, len(data), PositionBinarySize)
+	assert.Equals(t, data, []byte{
+		0x00, 0x00, 0x0f, 0xff, // black on squares 1-12
+		0xff, 0xf0, 0x00, 0x00, // red on squares 21-32
+		0x00, 0x00, 0x00, 0x00, // no kings
+		0x01, // black to move
+	})
+
+	var decoded Position
+	assert.True(t, decoded.UnmarshalBinary(data) == nil)
+	assert.True(t, decoded == position)
+
+	currentBoardStr := "" +
+		"|- - - - - - - -|" +
+		"|- - x - - - - -|" +
+		"|- - - O - - - -|" +
+		"|- - - - - - - -|" +
+		"|- - - - - - - -|" +
+		"|- - - - X - - -|" +
+		"|- - - o - - - -|" +
+		"|- - - - - - - -|"
+	position = Position{Board: NewBoard(currentBoardStr), Player: RED_PLAYER}
+	data, err = position.MarshalBinary()
+	assert.True(t, err == nil)
+	assert.True(t, decoded.UnmarshalBinary(data) == nil)
+	assert.True(t, decoded == position)
+
+}
+
+func TestPositionBinaryErrors(t *testing.T) {
+
+	board := NewEmptyBoard()
+	board[0][0] = RED
+	_, err := Position{Board: board}.MarshalBinary()
+	assert.True(t, err != nil)
+
+	// the variant can't be encoded
+	_, err = RUSSIAN_VARIANT.NewStartingPosition().MarshalBinary()
+	assert.True(t, err != nil)
+	_, err = Position{Board: NewStartingBoard(), Variant: ENGLISH_VARIANT}.MarshalBinary()
+	assert.True(t, err == nil)
+
+	var decoded Position
+	assert.True(t, decoded.UnmarshalBinary([]byte{0x00}) != nil)
+
+	// a square with both a black and a red piece
+	data := []byte{0, 0, 0, 1, 0, 0, 0, 1, 0, 0, 0, 0, 0}
+	assert.True(t, decoded.UnmarshalBinary(data) != nil)
+
+	// a king on an empty square
+	data = []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0}
+	assert.True(t, decoded.UnmarshalBinary(data) != nil)
+
+	// an invalid player
+	data = []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2}
+	assert.True(t, decoded.UnmarshalBinary(data) != nil)
+
+}
+
+func TestPositionStream(t *testing.T) {
+
+	positions := []Position{NewStartingPosition()}
+	for i := 0; i < 10; i++ {
+		last := positions[len(positions)-1]
+		positions = append(positions, last.ApplyMove(last.LegalMoves()[0]))
+	}
+
+	buffer := bytes.Buffer{}
+	writer := NewPositionWriter(&buffer)
+	for _, position := range positions {
+		assert.True(t, writer.Write(position) == nil)
+	}
+	assert.True(t, writer.Flush() == nil)
+	assert.Equals(t, buffer.Len(), len(positions)*PositionBinarySize)
+
+	reader := NewPositionReader(&buffer)
+	for _, position := range positions {
+		decoded, err := reader.Read()
+		assert.True(t, err == nil)
+		assert.True(t, decoded == position)
+	}
+	_, err := reader.Read()
+	assert.Equals(t, err, io.EOF)
+
+	reader = NewPositionReader(bytes.NewReader([]byte{0x00, 0x01}))
+	_, err = reader.Read()
+	assert.Equals(t, err, io.ErrUnexpectedEOF)
+
+}
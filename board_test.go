@@ -28,6 +28,23 @@ func TestNewBoard(t *testing.T) {
 
 }
 
+func TestParseBoard(t *testing.T) {
+
+	board, err := ParseBoard(NewStartingBoard().CompactString(true))
+	assert.True(t, err == nil)
+	assert.True(t, board == NewStartingBoard())
+
+	_, err = ParseBoard("|- o - o|")
+	assert.True(t, err != nil)
+
+	_, err = ParseBoard(NewStartingBoard().CompactString(false) + "|- o|")
+	assert.True(t, err != nil)
+
+	_, err = ParseBoard("|- o - o")
+	assert.True(t, err != nil)
+
+}
+
 func TestIsOnOpponentsFirstRank(t *testing.T) {
 	currentBoardStr := "" +
 		"|- - - - - - - -|" +
@@ -304,8 +321,8 @@ func TestAlternateSingleStepJumpPaths(t *testing.T) {
 
 	board := NewBoard(currentBoardStr)
 	loc := Location{row: 2, col: 2}
-	boardMoves := board.alternateSingleStepJumpPaths(RED_PLAYER, loc)
-	assert.Equals(t, len(boardMoves), 4)
+	jumpMoves := board.singleJumpMovesForLocation(RED_PLAYER, loc)
+	assert.Equals(t, len(jumpMoves), 4)
 
 }
 
@@ -609,16 +626,7 @@ func TestLegalMoves(t *testing.T) {
 	// x == red piece
 	// X == red king
 	// - == unoccupied square (may be legal dark or illegal white)
-	currentBoardStr := "" +
-		"|- o - o - o - o|" +
-		"|o - o - o - o -|" +
-		"|- o - o - o - o|" +
-		"|- - - - - - - -|" +
-		"|- - - - - - - -|" +
-		"|x - x - x - x -|" +
-		"|- x - x - x - x|" +
-		"|x - x - x - x -|"
-	board := NewBoard(currentBoardStr)
+	board := NewStartingBoard()
 
 	legalMoves := board.LegalMoves(BLACK_PLAYER)
 	assert.Equals(t, len(legalMoves), 7)
@@ -626,7 +634,7 @@ func TestLegalMoves(t *testing.T) {
 	legalMoves = board.LegalMoves(RED_PLAYER)
 	assert.Equals(t, len(legalMoves), 7)
 
-	currentBoardStr = "" +
+	currentBoardStr := "" +
 		"|- o - o - o - o|" +
 		"|- - o - o - o -|" +
 		"|- o - o - o - o|" +
@@ -0,0 +1,259 @@
+package checkerscore
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/*
+An opening book maps positions (keyed by Board.Hash) to the moves that
+have been played from them, along with a weight and the results of the
+games in which they were played.
+
+Books are saved as plain text, one move per line:
+
+	# hash            move   weight wins draws losses
+	4f3c9d2a8b7e6f10  11-15  120    48   50    22
+
+where wins, draws and losses are from the point of view of the player
+making the move.  The weight controls how often RandomMove picks the
+move, and defaults to the number of times it was played.
+*/
+
+type OpeningBook struct {
+	entries map[uint64][]BookEntry
+}
+
+type BookEntry struct {
+	Notation string
+	Weight   int
+	Wins     int
+	Draws    int
+	Losses   int
+}
+
+type BookMove struct {
+	Move Move
+	BookEntry
+}
+
+func NewOpeningBook() *OpeningBook {
+	return &OpeningBook{
+		entries: map[uint64][]BookEntry{},
+	}
+}
+
+// Build a book from the first maxPly moves of each game.  Games with
+// an unknown result only contribute to the weights.
+func BuildOpeningBook(games []PDNGame, maxPly int) (*OpeningBook, error) {
+
+	book := NewOpeningBook()
+	for i, game := range games {
+		ply := 0
+		visit := func(board Board, player Player, move Move) {
+			if ply < maxPly {
+				book.Add(board, player, move, game.Result)
+			}
+			ply += 1
+		}
+		if err := game.Replay(visit); err != nil {
+			return nil, fmt.Errorf("game %d: %v", i+1, err)
+		}
+	}
+	return book, nil
+
+}
+
+// Record that move was played by player from board in a game with the
+// given result.
+func (book *OpeningBook) Add(board Board, player Player, move Move, result GameResult) {
+
+	hash := board.Hash(player)
+	notation := move.Notation()
+
+	entries := book.entries[hash]
+	index := -1
+	for i, entry := range entries {
+		if entry.Notation == notation {
+			index = i
+		}
+	}
+	if index == -1 {
+		entries = append(entries, BookEntry{Notation: notation})
+		index = len(entries) - 1
+	}
+
+	entry := &entries[index]
+	entry.Weight += 1
+	if result != UNKNOWN_RESULT {
+		switch result.ScoreFor(player) {
+		case 1.0:
+			entry.Wins += 1
+		case 0.5:
+			entry.Draws += 1
+		default:
+			entry.Losses += 1
+		}
+	}
+	book.entries[hash] = entries
+
+}
+
+// The book moves available to player from board.  Entries that are not
+// legal moves on this board (eg, due to a hash collision) are skipped.
+func (book *OpeningBook) Lookup(board Board, player Player) []BookMove {
+
+	bookMoves := []BookMove{}
+	for _, entry := range book.entries[board.Hash(player)] {
+		move, err := board.ParseMove(player, entry.Notation)
+		if err != nil {
+			continue
+		}
+		bookMoves = append(bookMoves, BookMove{Move: move, BookEntry: entry})
+	}
+	return bookMoves
+
+}
+
+// The book move with the best score, breaking ties by weight.
+func (book *OpeningBook) BestMove(board Board, player Player) (Move, bool) {
+
+	bookMoves := book.Lookup(board, player)
+	if len(bookMoves) == 0 {
+		return Move{}, false
+	}
+
+	best := bookMoves[0]
+	for _, bookMove := range bookMoves[1:] {
+		switch {
+		case bookMove.Score() > best.Score():
+			best = bookMove
+		case bookMove.Score() == best.Score() && bookMove.Weight > best.Weight:
+			best = bookMove
+		}
+	}
+	return best.Move, true
+
+}
+
+// A book move chosen at random with probability proportional to its weight.
+func (book *OpeningBook) RandomMove(board Board, player Player, rng *rand.Rand) (Move, bool) {
+
+	bookMoves := book.Lookup(board, player)
+	totalWeight := 0
+	for _, bookMove := range bookMoves {
+		if bookMove.Weight > 0 {
+			totalWeight += bookMove.Weight
+		}
+	}
+	if totalWeight == 0 {
+		return Move{}, false
+	}
+
+	pick := rng.Intn(totalWeight)
+	for _, bookMove := range bookMoves {
+		if bookMove.Weight <= 0 {
+			continue
+		}
+		if pick < bookMove.Weight {
+			return bookMove.Move, true
+		}
+		pick -= bookMove.Weight
+	}
+	panic("unreachable")
+
+}
+
+// The fraction of points scored with this move, counting draws as half
+// a point, or 0.5 if there are no finished games.
+func (entry BookEntry) Score() float64 {
+	games := entry.Wins + entry.Draws + entry.Losses
+	if games == 0 {
+		return 0.5
+	}
+	return (float64(entry.Wins) + 0.5*float64(entry.Draws)) / float64(games)
+}
+
+func (book *OpeningBook) Save(writer io.Writer) error {
+
+	hashes := []uint64{}
+	for hash := range book.entries {
+		hashes = append(hashes, hash)
+	}
+	sort.Slice(hashes, func(i, j int) bool { return hashes[i] < hashes[j] })
+
+	if _, err := fmt.Fprintln(writer, "# hash move weight wins draws losses"); err != nil {
+		return err
+	}
+	for _, hash := range hashes {
+		for _, entry := range book.entries[hash] {
+			_, err := fmt.Fprintf(
+				writer,
+				"%016x %s %d %d %d %d\n",
+				hash,
+				entry.Notation,
+				entry.Weight,
+				entry.Wins,
+				entry.Draws,
+				entry.Losses,
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+
+}
+
+func LoadOpeningBook(reader io.Reader) (*OpeningBook, error) {
+
+	book := NewOpeningBook()
+	scanner := bufio.NewScanner(reader)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber += 1
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 6 {
+			return nil, fmt.Errorf("line %d: expected 6 fields, got %d", lineNumber, len(fields))
+		}
+		hash, err := strconv.ParseUint(fields[0], 16, 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid hash: %v", lineNumber, err)
+		}
+		if _, err := parseNotationSquares(fields[1]); err != nil {
+			return nil, fmt.Errorf("line %d: %v", lineNumber, err)
+		}
+		numbers := [4]int{}
+		for i := range numbers {
+			numbers[i], err = strconv.Atoi(fields[i+2])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid number: %v", lineNumber, err)
+			}
+		}
+
+		entry := BookEntry{
+			Notation: fields[1],
+			Weight:   numbers[0],
+			Wins:     numbers[1],
+			Draws:    numbers[2],
+			Losses:   numbers[3],
+		}
+		book.entries[hash] = append(book.entries[hash], entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return book, nil
+
+}
@@ -0,0 +1,95 @@
+package checkerscore
+
+import (
+	"bytes"
+	"github.com/couchbaselabs/go.assert"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestHashDependsOnPlayerAndPieces(t *testing.T) {
+	board := NewStartingBoard()
+	assert.Equals(t, board.Hash(BLACK_PLAYER), NewBoardFromBoard(board).Hash(BLACK_PLAYER))
+	assert.NotEquals(t, board.Hash(BLACK_PLAYER), board.Hash(RED_PLAYER))
+
+	move, _ := board.ParseMove(BLACK_PLAYER, "11-15")
+	boardPostMove := board.ApplyMove(BLACK_PLAYER, move)
+	assert.NotEquals(t, board.Hash(RED_PLAYER), boardPostMove.Hash(RED_PLAYER))
+}
+
+func TestBuildOpeningBook(t *testing.T) {
+
+	games, err := ReadPDN(strings.NewReader(testPDN))
+	assert.True(t, err == nil)
+
+	book, err := BuildOpeningBook(games, 2)
+	assert.True(t, err == nil)
+
+	board := NewStartingBoard()
+	bookMoves := book.Lookup(board, BLACK_PLAYER)
+	assert.Equals(t, len(bookMoves), 1)
+	assert.Equals(t, bookMoves[0].Notation, "11-15")
+	assert.Equals(t, bookMoves[0].Weight, 2)
+	assert.Equals(t, bookMoves[0].Wins, 1)
+	assert.Equals(t, bookMoves[0].Draws, 1)
+	assert.Equals(t, bookMoves[0].Score(), 0.75)
+
+	// the book is keyed by side to move as well as the position
+	assert.Equals(t, len(book.Lookup(board, RED_PLAYER)), 0)
+
+	move, _ := board.ParseMove(BLACK_PLAYER, "11-15")
+	board = board.ApplyMove(BLACK_PLAYER, move)
+	bookMoves = book.Lookup(board, RED_PLAYER)
+	assert.Equals(t, len(bookMoves), 2)
+
+	// 23-19 won for black, 24-19 was drawn, so red prefers 24-19
+	bestMove, ok := book.BestMove(board, RED_PLAYER)
+	assert.True(t, ok)
+	assert.Equals(t, bestMove.Notation(), "24-19")
+
+	// moves beyond maxPly are not in the book
+	move, _ = board.ParseMove(RED_PLAYER, "23-19")
+	board = board.ApplyMove(RED_PLAYER, move)
+	_, ok = book.BestMove(board, BLACK_PLAYER)
+	assert.False(t, ok)
+
+}
+
+func TestOpeningBookRandomMove(t *testing.T) {
+
+	board := NewStartingBoard()
+	book := NewOpeningBook()
+	move, _ := board.ParseMove(BLACK_PLAYER, "11-15")
+	book.Add(board, BLACK_PLAYER, move, UNKNOWN_RESULT)
+
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 10; i++ {
+		randomMove, ok := book.RandomMove(board, BLACK_PLAYER, rng)
+		assert.True(t, ok)
+		assert.Equals(t, randomMove.Notation(), "11-15")
+	}
+
+	_, ok := book.RandomMove(board, RED_PLAYER, rng)
+	assert.False(t, ok)
+
+}
+
+func TestOpeningBookSaveLoad(t *testing.T) {
+
+	games, err := ReadPDN(strings.NewReader(testPDN))
+	assert.True(t, err == nil)
+	book, err := BuildOpeningBook(games, 4)
+	assert.True(t, err == nil)
+
+	buffer := bytes.Buffer{}
+	assert.True(t, book.Save(&buffer) == nil)
+
+	loadedBook, err := LoadOpeningBook(&buffer)
+	assert.True(t, err == nil)
+	assert.Equals(t, loadedBook.entries, book.entries)
+
+	_, err = LoadOpeningBook(strings.NewReader("0123 11-15 1 0 0"))
+	assert.True(t, err != nil)
+
+}
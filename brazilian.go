@@ -0,0 +1,20 @@
+package checkerscore
+
+/*
+Brazilian draughts: the international rules on an 8x8 board.  Men capture
+backwards, kings fly, the capture taking the most pieces must be played,
+and a man is only promoted if its move ends on the last row.  The pieces
+start on the same squares as in English checkers, but white (red) moves
+first.
+*/
+var BRAZILIAN_VARIANT = &Variant{
+	Name:                "brazilian",
+	MenCaptureBackwards: true,
+	FlyingKings:         true,
+	MaximumCapture:      true,
+}
+
+func init() {
+	registerVariant(BRAZILIAN_VARIANT)
+	registerStartingPosition("brazilian", englishStartingBoard, RED_PLAYER)
+}
@@ -0,0 +1,31 @@
+package checkerscore
+
+import (
+	"github.com/couchbaselabs/go.assert"
+	"testing"
+)
+
+func TestBrazilianPerft(t *testing.T) {
+	position := BRAZILIAN_VARIANT.NewStartingPosition()
+	assert.Equals(t, position.Player, RED_PLAYER)
+	expected := []int{7, 49, 302, 1469, 7473, 37628}
+	for i, count := range expected {
+		assert.Equals(t, position.Perft(i+1), count)
+	}
+}
+
+func TestBrazilianCaptures(t *testing.T) {
+
+	// only the capture taking the most pieces may be played
+	position, _ := ParseFEN("W:W17,31:B22,14,7,27")
+	position.Variant = BRAZILIAN_VARIANT
+	assert.Equals(t, sortedNotations(position.LegalMoves()), []string{"17x10x3"})
+
+	// a man passing over the last row carries on as a man
+	position, _ = ParseFEN("W:W11:B7,6")
+	position.Variant = BRAZILIAN_VARIANT
+	moves := position.LegalMoves()
+	assert.Equals(t, sortedNotations(moves), []string{"11x2x9"})
+	assert.Equals(t, position.ApplyMove(moves[0]).Board.PieceAt(moves[0].To()), RED)
+
+}
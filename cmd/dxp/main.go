@@ -0,0 +1,91 @@
+/*
+Play checkers games against another engine over TCP using DXP.
+
+One side listens for a connection and follows, accepting the game
+request it receives:
+
+	dxp -listen :27531 -depth 8
+
+and the other connects and initiates the game, choosing its own color:
+
+	dxp -connect localhost:27531 -color black -depth 6
+
+The initiator's -minutes and -moves set the time control, eg 5 minutes
+for every 50 moves, which both sides use to share out their thinking
+time.  Each side declares the game drawn once both have made the number
+of moves given by its own -draw.
+
+Both sides log the moves and the result of the game to stderr.
+*/
+package main
+
+import (
+	"flag"
+	"log"
+	"net"
+	"os"
+
+	core "github.com/tleyden/checkers-core"
+	"github.com/tleyden/checkers-core/dxp"
+)
+
+func main() {
+
+	listenAddr := flag.String("listen", "", "address to listen on, eg :27531")
+	connectAddr := flag.String("connect", "", "address of the engine to play, eg localhost:27531")
+	name := flag.String("name", "checkers-core", "engine name sent to the opponent")
+	color := flag.String("color", "black", "color to play when connecting (black or red)")
+	depth := flag.Int("depth", 6, "search depth")
+	minutes := flag.Int("minutes", 5, "thinking time requested when connecting")
+	moves := flag.Int("moves", 50, "number of moves to be played in that time")
+	drawAfter := flag.Int("draw", 100, "moves by each side before the game is declared drawn, or 0 for no limit")
+	flag.Parse()
+
+	m := &match{
+		name:      *name,
+		limits:    core.SearchLimits{Depth: *depth},
+		eval:      core.DefaultEvaluationFunction(),
+		logger:    log.New(os.Stderr, "", log.LstdFlags),
+		minutes:   *minutes,
+		moves:     *moves,
+		drawAfter: *drawAfter,
+	}
+
+	var result core.GameResult
+	var err error
+	switch {
+	case *listenAddr != "":
+		var listener net.Listener
+		listener, err = net.Listen("tcp", *listenAddr)
+		if err != nil {
+			log.Fatal(err)
+		}
+		var conn net.Conn
+		conn, err = listener.Accept()
+		listener.Close()
+		if err != nil {
+			log.Fatal(err)
+		}
+		result, err = m.follow(dxp.NewConn(conn))
+	case *connectAddr != "":
+		self := core.BLACK_PLAYER
+		if *color == "red" {
+			self = core.RED_PLAYER
+		}
+		var conn net.Conn
+		conn, err = net.Dial("tcp", *connectAddr)
+		if err != nil {
+			log.Fatal(err)
+		}
+		result, err = m.initiate(dxp.NewConn(conn), self)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		log.Fatal(err)
+	}
+	m.logger.Printf("result: %v", result)
+
+}
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	core "github.com/tleyden/checkers-core"
+	"github.com/tleyden/checkers-core/dxp"
+)
+
+// Plays a single game against a remote engine
+type match struct {
+	name   string
+	limits core.SearchLimits
+	eval   core.EvaluationFunction
+	logger *log.Logger
+
+	// the time control: minutes of thinking time for the given number of
+	// moves, sent in the game request or taken from the one received
+	minutes int
+	moves   int
+
+	// the game is declared drawn once both sides have made this many
+	// moves, or never if it's 0
+	drawAfter int
+
+	conn    *dxp.Conn
+	self    core.Player
+	history []core.Position // the positions before each move played
+}
+
+// Request a game where we play self, and play it
+func (m *match) initiate(conn *dxp.Conn, self core.Player) (core.GameResult, error) {
+
+	defer conn.Close()
+	m.conn = conn
+	m.self = self
+
+	request := dxp.GameRequest{
+		Name:          m.name,
+		FollowerColor: self.Opponent(),
+		Minutes:       m.minutes,
+		Moves:         m.moves,
+	}
+	if err := conn.Send(request); err != nil {
+		return core.UNKNOWN_RESULT, err
+	}
+	message, err := conn.Receive()
+	if err != nil {
+		return core.UNKNOWN_RESULT, err
+	}
+	accept, ok := message.(dxp.GameAccept)
+	if !ok {
+		return core.UNKNOWN_RESULT, fmt.Errorf("expected game accept, got %v", message.Encode())
+	}
+	if accept.AcceptCode != dxp.ACCEPT {
+		return core.UNKNOWN_RESULT, fmt.Errorf("%v refused the game: code %d", accept.Name, accept.AcceptCode)
+	}
+	m.logger.Printf("playing %v as %v", accept.Name, self)
+
+	return m.play(core.NewStartingPosition())
+
+}
+
+// Wait for a game request, accept it and play the game
+func (m *match) follow(conn *dxp.Conn) (core.GameResult, error) {
+
+	defer conn.Close()
+	m.conn = conn
+
+	message, err := conn.Receive()
+	if err != nil {
+		return core.UNKNOWN_RESULT, err
+	}
+	request, ok := message.(dxp.GameRequest)
+	if !ok {
+		return core.UNKNOWN_RESULT, fmt.Errorf("expected game request, got %v", message.Encode())
+	}
+
+	start := core.NewStartingPosition()
+	if request.Position != nil {
+		start = *request.Position
+	}
+	m.minutes = request.Minutes
+	m.moves = request.Moves
+	m.self = request.FollowerColor
+	if err := conn.Send(dxp.GameAccept{Name: m.name, AcceptCode: dxp.ACCEPT}); err != nil {
+		return core.UNKNOWN_RESULT, err
+	}
+	m.logger.Printf("playing %v as %v", request.Name, m.self)
+
+	return m.play(start)
+
+}
+
+func (m *match) play(start core.Position) (core.GameResult, error) {
+
+	m.history = []core.Position{}
+	position := start
+	for {
+		onMove := position.Player == m.self
+
+		if onMove {
+			switch {
+			case len(position.LegalMoves()) == 0:
+				return m.endGame(dxp.END_I_LOSE, winner(position.Player.Opponent()))
+			case m.drawAfter > 0 && len(m.history) >= 2*m.drawAfter:
+				return m.endGame(dxp.END_DRAW, core.DRAW)
+			}
+
+			limits := m.limits
+			if limits.MoveTime == 0 {
+				limits.MoveTime = m.moveTime()
+			}
+			start := time.Now()
+			info := position.Board.Search(position.Player, limits, m.eval, nil)
+			move, _ := info.BestMove()
+			seconds := int(time.Since(start).Seconds())
+			if err := m.conn.Send(dxp.NewMoveMessage(move, seconds)); err != nil {
+				return core.UNKNOWN_RESULT, err
+			}
+			m.logger.Printf("%v played %v", position.Player, move.Notation())
+			m.history = append(m.history, position)
+			position = position.ApplyMove(move)
+			continue
+		}
+
+		message, err := m.conn.Receive()
+		if err != nil {
+			return core.UNKNOWN_RESULT, err
+		}
+		switch message := message.(type) {
+		case dxp.MoveMessage:
+			move, err := message.ToMove(position)
+			if err != nil {
+				return core.UNKNOWN_RESULT, err
+			}
+			m.logger.Printf("%v played %v", position.Player, move.Notation())
+			m.history = append(m.history, position)
+			position = position.ApplyMove(move)
+		case dxp.GameEnd:
+			result := m.resultFromOpponent(message.Reason)
+			err := m.conn.Send(dxp.GameEnd{Reason: confirmReason(message.Reason), StopCode: 1})
+			return result, err
+		case dxp.Chat:
+			m.logger.Printf("chat: %v", message.Text)
+		case dxp.BackRequest:
+			restored, ok := m.backup(start, message)
+			code := dxp.BACK_REFUSE
+			if ok {
+				code = dxp.BACK_ACCEPT
+				position = restored
+			}
+			if err := m.conn.Send(dxp.BackAccept{AcceptCode: code}); err != nil {
+				return core.UNKNOWN_RESULT, err
+			}
+		default:
+			return core.UNKNOWN_RESULT, fmt.Errorf("unexpected message: %v", message.Encode())
+		}
+	}
+
+}
+
+// The thinking time for each move, sharing the time control's minutes
+// equally between its moves, or 0 if there's no time control
+func (m *match) moveTime() time.Duration {
+	if m.minutes <= 0 || m.moves <= 0 {
+		return 0
+	}
+	return time.Duration(m.minutes) * time.Minute / time.Duration(m.moves)
+}
+
+// Tell the opponent the game is over and wait for their confirmation
+func (m *match) endGame(reason int, result core.GameResult) (core.GameResult, error) {
+	if err := m.conn.Send(dxp.GameEnd{Reason: reason, StopCode: 1}); err != nil {
+		return core.UNKNOWN_RESULT, err
+	}
+	for {
+		message, err := m.conn.Receive()
+		if err != nil {
+			return core.UNKNOWN_RESULT, err
+		}
+		if _, ok := message.(dxp.GameEnd); ok {
+			return result, nil
+		}
+	}
+}
+
+// The result of a game ended by the opponent with the given reason
+func (m *match) resultFromOpponent(reason int) core.GameResult {
+	switch reason {
+	case dxp.END_I_LOSE:
+		return winner(m.self)
+	case dxp.END_I_WIN:
+		return winner(m.self.Opponent())
+	case dxp.END_DRAW:
+		return core.DRAW
+	default:
+		return core.UNKNOWN_RESULT
+	}
+}
+
+// Take back moves so that it's the given move number and player to move,
+// counting moves from one in the starting position.
+func (m *match) backup(start core.Position, request dxp.BackRequest) (core.Position, bool) {
+	ply := (request.MoveNumber - 1) * 2
+	if request.Player != start.Player {
+		ply += 1
+	}
+	if request.MoveNumber < 1 || ply >= len(m.history) {
+		return core.Position{}, false
+	}
+	position := m.history[ply]
+	m.history = m.history[:ply]
+	return position, true
+}
+
+func confirmReason(reason int) int {
+	switch reason {
+	case dxp.END_I_LOSE:
+		return dxp.END_I_WIN
+	case dxp.END_I_WIN:
+		return dxp.END_I_LOSE
+	default:
+		return reason
+	}
+}
+
+func winner(player core.Player) core.GameResult {
+	if player == core.BLACK_PLAYER {
+		return core.BLACK_WINS
+	}
+	return core.RED_WINS
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"github.com/couchbaselabs/go.assert"
+	"io/ioutil"
+	"log"
+	"net"
+	"testing"
+	"time"
+
+	core "github.com/tleyden/checkers-core"
+	"github.com/tleyden/checkers-core/dxp"
+)
+
+func newTestMatch(name string, depth int, drawAfter int) *match {
+	return &match{
+		name:      name,
+		limits:    core.SearchLimits{Depth: depth},
+		eval:      core.DefaultEvaluationFunction(),
+		logger:    log.New(ioutil.Discard, "", 0),
+		drawAfter: drawAfter,
+	}
+}
+
+// Play a game between two matches over localhost
+func TestMatchOverLocalhost(t *testing.T) {
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.True(t, err == nil)
+	defer listener.Close()
+
+	type outcome struct {
+		result core.GameResult
+		err    error
+	}
+	followerDone := make(chan outcome)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			followerDone <- outcome{err: err}
+			return
+		}
+		result, err := newTestMatch("follower", 1, 0).follow(dxp.NewConn(conn))
+		followerDone <- outcome{result, err}
+	}()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	assert.True(t, err == nil)
+	initiator := newTestMatch("initiator", 3, 20)
+	initiator.minutes, initiator.moves = 1, 600
+	initiatorResult, err := initiator.initiate(dxp.NewConn(conn), core.BLACK_PLAYER)
+	assert.True(t, err == nil)
+
+	follower := <-followerDone
+	assert.True(t, follower.err == nil)
+	assert.Equals(t, follower.result, initiatorResult)
+	assert.True(t, initiatorResult != core.UNKNOWN_RESULT)
+
+}
+
+// The follower announces its loss when it has no moves left
+func TestMatchGameEnd(t *testing.T) {
+
+	client, server := net.Pipe()
+	clientConn := dxp.NewConn(client)
+
+	position, _ := core.ParseFEN("W:W14:B")
+	followerDone := make(chan core.GameResult)
+	go func() {
+		result, _ := newTestMatch("follower", 1, 10).follow(dxp.NewConn(server))
+		followerDone <- result
+	}()
+
+	request := dxp.GameRequest{Name: "test", FollowerColor: core.RED_PLAYER, Moves: 10, Position: &position}
+	assert.True(t, clientConn.Send(request) == nil)
+	message, err := clientConn.Receive()
+	assert.True(t, err == nil)
+	assert.Equals(t, message.(dxp.GameAccept).AcceptCode, dxp.ACCEPT)
+
+	// red moves, then black has no pieces and announces the loss
+	message, err = clientConn.Receive()
+	assert.True(t, err == nil)
+	_, ok := message.(dxp.MoveMessage)
+	assert.True(t, ok)
+
+	assert.True(t, clientConn.Send(dxp.Chat{Text: "oops"}) == nil)
+	assert.True(t, clientConn.Send(dxp.GameEnd{Reason: dxp.END_I_LOSE}) == nil)
+	message, err = clientConn.Receive()
+	assert.True(t, err == nil)
+	assert.Equals(t, message, dxp.GameEnd{Reason: dxp.END_I_WIN, StopCode: 1})
+
+	assert.Equals(t, <-followerDone, core.RED_WINS)
+
+}
+
+func TestMatchBackup(t *testing.T) {
+
+	m := newTestMatch("test", 1, 10)
+	start := core.NewStartingPosition()
+	position := start
+	for i := 0; i < 4; i++ {
+		m.history = append(m.history, position)
+		position = position.ApplyMove(position.LegalMoves()[0])
+	}
+
+	// black moves first, so red's second move is the fourth ply
+	expected := m.history[3]
+	restored, ok := m.backup(start, dxp.BackRequest{MoveNumber: 2, Player: core.RED_PLAYER})
+	assert.True(t, ok)
+	assert.True(t, restored == expected)
+	assert.Equals(t, len(m.history), 3)
+	assert.Equals(t, restored.Player, core.RED_PLAYER)
+
+	_, ok = m.backup(start, dxp.BackRequest{MoveNumber: 5, Player: core.BLACK_PLAYER})
+	assert.False(t, ok)
+
+}
+
+// The follower takes the time control from the request, but keeps its
+// own draw limit
+func TestMatchTimeControl(t *testing.T) {
+
+	client, server := net.Pipe()
+	clientConn := dxp.NewConn(client)
+
+	follower := newTestMatch("follower", 1, 0)
+	followerDone := make(chan core.GameResult)
+	go func() {
+		result, _ := follower.follow(dxp.NewConn(server))
+		followerDone <- result
+	}()
+
+	request := dxp.GameRequest{Name: "test", FollowerColor: core.RED_PLAYER, Minutes: 2, Moves: 1}
+	assert.True(t, clientConn.Send(request) == nil)
+	message, err := clientConn.Receive()
+	assert.True(t, err == nil)
+	assert.Equals(t, message.(dxp.GameAccept).AcceptCode, dxp.ACCEPT)
+
+	// after one move each, the game carries on
+	assert.True(t, clientConn.Send(dxp.NewMoveMessage(core.NewStartingPosition().LegalMoves()[0], 0)) == nil)
+	message, err = clientConn.Receive()
+	assert.True(t, err == nil)
+	_, ok := message.(dxp.MoveMessage)
+	assert.True(t, ok)
+
+	assert.True(t, clientConn.Send(dxp.GameEnd{Reason: dxp.END_DRAW}) == nil)
+	_, err = clientConn.Receive()
+	assert.True(t, err == nil)
+	assert.Equals(t, <-followerDone, core.DRAW)
+	assert.Equals(t, follower.moveTime(), 2*time.Minute)
+	assert.Equals(t, follower.drawAfter, 0)
+
+}
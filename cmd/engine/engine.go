@@ -0,0 +1,184 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	core "github.com/tleyden/checkers-core"
+)
+
+type engine struct {
+	position      core.Position
+	eval          core.EvaluationFunction
+	defaultLimits core.SearchLimits
+	out           io.Writer
+}
+
+func newEngine(out io.Writer) *engine {
+	return &engine{
+		position:      core.NewStartingPosition(),
+		eval:          core.DefaultEvaluationFunction(),
+		defaultLimits: core.SearchLimits{Depth: 8},
+		out:           out,
+	}
+}
+
+// Handle commands from in until it's exhausted or "quit" is received
+func (e *engine) run(in io.Reader) error {
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		if !e.handle(scanner.Text()) {
+			return nil
+		}
+	}
+	return scanner.Err()
+}
+
+// Handle a single command, returning false if the engine should exit
+func (e *engine) handle(line string) bool {
+
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return true
+	}
+
+	var err error
+	switch fields[0] {
+	case "isready":
+		e.reply("readyok")
+	case "newgame":
+		e.position = core.NewStartingPosition()
+	case "position":
+		err = e.setPosition(fields[1:])
+	case "legalmoves":
+		e.reply("legalmoves %v", strings.Join(notations(e.position.LegalMoves()), " "))
+	case "go":
+		err = e.search(fields[1:])
+	case "quit":
+		return false
+	default:
+		err = fmt.Errorf("unknown command: %v", fields[0])
+	}
+
+	if err != nil {
+		e.reply("error %v", err)
+	}
+	return true
+
+}
+
+func (e *engine) setPosition(args []string) error {
+
+	if len(args) == 0 {
+		return fmt.Errorf("position requires startpos or fen")
+	}
+
+	var position core.Position
+	switch args[0] {
+	case "startpos":
+		position = core.NewStartingPosition()
+		args = args[1:]
+	case "fen":
+		if len(args) < 2 {
+			return fmt.Errorf("position fen requires a FEN")
+		}
+		var err error
+		position, err = core.ParseFEN(args[1])
+		if err != nil {
+			return err
+		}
+		args = args[2:]
+	default:
+		return fmt.Errorf("unknown position type: %v", args[0])
+	}
+
+	if len(args) > 0 {
+		if args[0] != "moves" {
+			return fmt.Errorf("expected moves, got %v", args[0])
+		}
+		for _, notation := range args[1:] {
+			move, err := position.Board.ParseMove(position.Player, notation)
+			if err != nil {
+				return err
+			}
+			position = position.ApplyMove(move)
+		}
+	}
+
+	e.position = position
+	return nil
+
+}
+
+func (e *engine) search(args []string) error {
+
+	limits, err := e.parseLimits(args)
+	if err != nil {
+		return err
+	}
+
+	progress := func(info core.SearchInfo) {
+		e.reply(
+			"info depth %d score %v nodes %d time %d pv %v",
+			info.Depth,
+			info.Score,
+			info.Nodes,
+			info.Elapsed.Nanoseconds()/1e6,
+			strings.Join(notations(info.PV), " "),
+		)
+	}
+	info := e.position.Board.Search(e.position.Player, limits, e.eval, progress)
+
+	bestMove, ok := info.BestMove()
+	if !ok {
+		e.reply("bestmove none")
+		return nil
+	}
+	e.reply("bestmove %v", bestMove.Notation())
+	return nil
+
+}
+
+func (e *engine) parseLimits(args []string) (core.SearchLimits, error) {
+
+	if len(args) == 0 {
+		return e.defaultLimits, nil
+	}
+	if len(args)%2 != 0 {
+		return core.SearchLimits{}, fmt.Errorf("go expects name/value pairs")
+	}
+
+	limits := core.SearchLimits{}
+	for i := 0; i < len(args); i += 2 {
+		value, err := strconv.Atoi(args[i+1])
+		// a zero limit would mean searching forever, as there's no stop command
+		if err != nil || value <= 0 {
+			return core.SearchLimits{}, fmt.Errorf("invalid %v: %v", args[i], args[i+1])
+		}
+		switch args[i] {
+		case "depth":
+			limits.Depth = value
+		case "movetime":
+			limits.MoveTime = millis(value)
+		default:
+			return core.SearchLimits{}, fmt.Errorf("unknown limit: %v", args[i])
+		}
+	}
+	return limits, nil
+
+}
+
+func (e *engine) reply(format string, args ...interface{}) {
+	fmt.Fprintf(e.out, format+"\n", args...)
+}
+
+func notations(moves []core.Move) []string {
+	result := []string{}
+	for _, move := range moves {
+		result = append(result, move.Notation())
+	}
+	return result
+}
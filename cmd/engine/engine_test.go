@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"github.com/couchbaselabs/go.assert"
+	"strings"
+	"testing"
+)
+
+func runEngine(t *testing.T, commands string) []string {
+	out := bytes.Buffer{}
+	e := newEngine(&out)
+	assert.True(t, e.run(strings.NewReader(commands)) == nil)
+	return strings.Split(strings.TrimSpace(out.String()), "\n")
+}
+
+func TestEngineLegalMoves(t *testing.T) {
+
+	lines := runEngine(t, "isready\nposition startpos moves 11-15 23-19\nlegalmoves\n")
+	assert.Equals(t, lines[0], "readyok")
+	assert.True(t, strings.HasPrefix(lines[1], "legalmoves "))
+	assert.True(t, strings.Contains(lines[1], " 8-11"))
+	assert.False(t, strings.Contains(lines[1], "23-19"))
+
+	lines = runEngine(t, "position fen W:W21:B17\nlegalmoves\nnewgame\nlegalmoves\n")
+	assert.Equals(t, lines[0], "legalmoves 21x14")
+	assert.Equals(t, lines[1], "legalmoves 9-14 9-13 10-15 10-14 11-16 11-15 12-16")
+
+}
+
+func TestEngineGo(t *testing.T) {
+
+	lines := runEngine(t, "position fen W:W14,15:B5\ngo depth 3\nquit\nisready\n")
+	assert.Equals(t, len(lines), 4)
+	assert.True(t, strings.HasPrefix(lines[0], "info depth 1 score "))
+	assert.True(t, strings.HasPrefix(lines[2], "info depth 3 score "))
+	assert.True(t, strings.HasPrefix(lines[3], "bestmove "))
+
+	lines = runEngine(t, "position fen B:W14:B\ngo movetime 10\n")
+	assert.Equals(t, lines[len(lines)-1], "bestmove none")
+
+}
+
+func TestEngineErrors(t *testing.T) {
+
+	commands := []string{
+		"bogus",
+		"position",
+		"position fen",
+		"position fen X:W:B",
+		"position startpos moves 11-16 11-15",
+		"position startpos 11-15",
+		"go depth",
+		"go depth three",
+		"go nodes 100",
+		"go depth 0",
+		"go depth 4 movetime 0",
+	}
+	lines := runEngine(t, strings.Join(commands, "\n"))
+	assert.Equals(t, len(lines), len(commands))
+	for _, line := range lines {
+		assert.True(t, strings.HasPrefix(line, "error "))
+	}
+
+}
@@ -0,0 +1,70 @@
+/*
+A checkers engine speaking a line based text protocol over stdin/stdout,
+so that it can be driven by GUIs and match managers.
+
+Commands, one per line:
+
+	isready                               replies "readyok"
+	newgame                               resets to the starting position
+	position startpos [moves <m1> ...]    sets up the starting position
+	position fen <fen> [moves <m1> ...]   sets up a position from a PDN FEN
+	legalmoves                            replies "legalmoves <m1> <m2> ..."
+	go [depth <plies>] [movetime <ms>]    searches the current position
+	quit                                  exits
+
+Moves use standard notation, eg "11-15" or "15x24x31".  While searching,
+the engine reports each completed iteration:
+
+	info depth 6 score 0.3 nodes 12345 time 42 pv 11-15 23-19 8-11
+
+and finishes with "bestmove <move>", or "bestmove none" if there are no
+legal moves.  The depth and movetime limits must be positive.  Invalid
+commands are answered with "error <message>".
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	core "github.com/tleyden/checkers-core"
+)
+
+func main() {
+
+	depth := flag.Int("depth", 8, "search depth used when go has no limits")
+	moveTime := flag.Duration("movetime", 0, "search time used when go has no limits")
+	weightsPath := flag.String("weights", "", "evaluation weights file, eg written by cmd/tune")
+	flag.Parse()
+
+	e := newEngine(os.Stdout)
+	e.defaultLimits = core.SearchLimits{Depth: *depth, MoveTime: *moveTime}
+	if *weightsPath != "" {
+		weights, err := readWeights(*weightsPath)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		e.eval = weights.EvaluationFunction()
+	}
+	if err := e.run(os.Stdin); err != nil {
+		os.Exit(1)
+	}
+
+}
+
+// Convert a protocol time in milliseconds to a duration
+func millis(ms int) time.Duration {
+	return time.Duration(ms) * time.Millisecond
+}
+
+func readWeights(path string) (core.EvalWeights, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return core.EvalWeights{}, err
+	}
+	defer file.Close()
+	return core.ReadEvalWeights(file)
+}
@@ -0,0 +1,49 @@
+/*
+A server hosting live games between humans and bots over WebSockets.
+
+Create a game by POSTing the seats (and optionally a starting FEN and the
+bot search depth) to /games:
+
+	{"black": "human", "red": "bot", "depth": 6}
+
+which responds with {"id": "1"}.  Players and spectators then connect to
+/games/<id>/ws?seat=black (or red, or watch).  Every connection receives
+the game state as JSON whenever it changes:
+
+	{"type": "update", "game": {"id": "1", "fen": "...", "board": [...],
+	 "player": "black", "moves": ["11-15", ...], "legal_moves": [...],
+	 "result": "unknown", "over": false, "seats": {...}}}
+
+and a human player submits moves for their seat with:
+
+	{"type": "move", "move": "11-15"}
+
+Invalid moves are answered with {"type": "error", "error": "..."}.  Bot
+seats search in the background and play as soon as it's their turn.
+Finished games are removed, and their connections closed, once the time
+given by -keep has passed.
+
+The WebSocket support comes from golang.org/x/net/websocket, which must
+be installed alongside this package:
+
+	go get golang.org/x/net/websocket
+*/
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"time"
+)
+
+func main() {
+	addr := flag.String("addr", ":8081", "address to listen on")
+	keep := flag.Duration("keep", 10*time.Minute, "how long to keep finished games")
+	flag.Parse()
+
+	server := newGameServer()
+	server.finishedTTL = *keep
+	log.Printf("listening on %v", *addr)
+	log.Fatal(http.ListenAndServe(*addr, server))
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	core "github.com/tleyden/checkers-core"
+	"golang.org/x/net/websocket"
+)
+
+type gameServer struct {
+	mutex    sync.Mutex
+	sessions map[string]*session
+	nextID   int
+	maxDepth int
+
+	// how long a finished game is kept, so that its players can see the
+	// result, before it's removed and its clients disconnected
+	finishedTTL time.Duration
+}
+
+type createGameRequest struct {
+	Black string `json:"black"`
+	Red   string `json:"red"`
+	Depth int    `json:"depth"`
+	FEN   string `json:"fen"`
+}
+
+func newGameServer() *gameServer {
+	return &gameServer{
+		sessions:    map[string]*session{},
+		nextID:      1,
+		maxDepth:    10,
+		finishedTTL: 10 * time.Minute,
+	}
+}
+
+func (gs *gameServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	path := strings.Trim(r.URL.Path, "/")
+	parts := strings.Split(path, "/")
+	switch {
+	case path == "games" && r.Method == "POST":
+		gs.createGame(w, r)
+	case path == "games" && r.Method == "GET":
+		gs.listGames(w)
+	case len(parts) == 3 && parts[0] == "games" && parts[2] == "ws":
+		id := parts[1]
+		if gs.session(id) == nil {
+			http.NotFound(w, r)
+			return
+		}
+		seat := r.URL.Query().Get("seat")
+		if seat == "" {
+			seat = "watch"
+		}
+		if seat != "red" && seat != "black" && seat != "watch" {
+			http.Error(w, "seat must be red, black or watch", http.StatusBadRequest)
+			return
+		}
+		websocket.Handler(func(ws *websocket.Conn) {
+			gs.serveClient(id, seat, ws)
+		}).ServeHTTP(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+
+}
+
+func (gs *gameServer) createGame(w http.ResponseWriter, r *http.Request) {
+
+	request := createGameRequest{Black: HUMAN, Red: BOT, Depth: 6}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, fmt.Sprintf("invalid JSON: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	seats := map[core.Player]string{
+		core.BLACK_PLAYER: request.Black,
+		core.RED_PLAYER:   request.Red,
+	}
+	for _, seat := range seats {
+		if seat != HUMAN && seat != BOT {
+			http.Error(w, "seats must be human or bot", http.StatusBadRequest)
+			return
+		}
+	}
+	if request.Depth < 1 || request.Depth > gs.maxDepth {
+		http.Error(w, fmt.Sprintf("depth must be between 1 and %d", gs.maxDepth), http.StatusBadRequest)
+		return
+	}
+	start := core.NewStartingPosition()
+	if request.FEN != "" {
+		var err error
+		start, err = core.ParseFEN(request.FEN)
+		if err == nil {
+			err = start.Board.Validate()
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	gs.mutex.Lock()
+	id := strconv.Itoa(gs.nextID)
+	gs.nextID += 1
+	s := newSession(id, start, seats, core.SearchLimits{Depth: request.Depth})
+	s.onFinished = func() {
+		time.AfterFunc(gs.finishedTTL, func() { gs.removeSession(id) })
+	}
+	gs.sessions[id] = s
+	gs.mutex.Unlock()
+
+	// a bot playing first starts straight away
+	s.mutex.Lock()
+	s.checkFinished()
+	s.startBotIfNeeded()
+	s.mutex.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]string{"id": id})
+
+}
+
+func (gs *gameServer) listGames(w http.ResponseWriter) {
+	gs.mutex.Lock()
+	states := []*gameState{}
+	for _, s := range gs.sessions {
+		s.mutex.Lock()
+		states = append(states, s.updateMessage().Game)
+		s.mutex.Unlock()
+	}
+	gs.mutex.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(states)
+}
+
+func (gs *gameServer) session(id string) *session {
+	gs.mutex.Lock()
+	defer gs.mutex.Unlock()
+	return gs.sessions[id]
+}
+
+// Forget a session, disconnecting its clients
+func (gs *gameServer) removeSession(id string) {
+	gs.mutex.Lock()
+	s := gs.sessions[id]
+	delete(gs.sessions, id)
+	gs.mutex.Unlock()
+	if s != nil {
+		s.close()
+	}
+}
+
+// Join the session with the given id, if it hasn't been removed
+func (gs *gameServer) join(id, seat string) (*session, *client) {
+	gs.mutex.Lock()
+	defer gs.mutex.Unlock()
+	s := gs.sessions[id]
+	if s == nil {
+		return nil, nil
+	}
+	return s, s.join(seat)
+}
+
+// Relay messages between a WebSocket connection and a session until the
+// connection closes
+func (gs *gameServer) serveClient(id, seat string, ws *websocket.Conn) {
+
+	s, c := gs.join(id, seat)
+	if s == nil {
+		ws.Close()
+		return
+	}
+	defer s.leave(c)
+
+	go func() {
+		for message := range c.send {
+			if err := websocket.JSON.Send(ws, message); err != nil {
+				ws.Close()
+				return
+			}
+		}
+		ws.Close()
+	}()
+
+	for {
+		message := clientMessage{}
+		if err := websocket.JSON.Receive(ws, &message); err != nil {
+			return
+		}
+		switch message.Type {
+		case "move":
+			s.submitMove(c, message.Move)
+		default:
+			s.reply(c, serverMessage{Type: "error", Error: "unknown message type: " + message.Type})
+		}
+	}
+
+}
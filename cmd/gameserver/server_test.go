@@ -0,0 +1,149 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/couchbaselabs/go.assert"
+	"golang.org/x/net/websocket"
+)
+
+func createGame(t *testing.T, url, body string) string {
+	resp, err := http.Post(url+"/games", "application/json", strings.NewReader(body))
+	assert.True(t, err == nil)
+	defer resp.Body.Close()
+	assert.Equals(t, resp.StatusCode, http.StatusCreated)
+	created := map[string]string{}
+	assert.True(t, jsonDecode(resp, &created) == nil)
+	return created["id"]
+}
+
+func connect(t *testing.T, url, id, seat string) *websocket.Conn {
+	wsURL := "ws" + strings.TrimPrefix(url, "http") + "/games/" + id + "/ws?seat=" + seat
+	ws, err := websocket.Dial(wsURL, "", url)
+	assert.True(t, err == nil)
+	ws.SetDeadline(time.Now().Add(10 * time.Second))
+	return ws
+}
+
+func receive(t *testing.T, ws *websocket.Conn) serverMessage {
+	message := serverMessage{}
+	err := websocket.JSON.Receive(ws, &message)
+	assert.True(t, err == nil)
+	return message
+}
+
+func TestHumanVersusBot(t *testing.T) {
+
+	server := httptest.NewServer(newGameServer())
+	defer server.Close()
+
+	id := createGame(t, server.URL, `{"black": "human", "red": "bot", "depth": 2}`)
+	black := connect(t, server.URL, id, "black")
+	defer black.Close()
+	watcher := connect(t, server.URL, id, "watch")
+	defer watcher.Close()
+
+	message := receive(t, black)
+	assert.Equals(t, message.Type, "update")
+	assert.Equals(t, len(message.Game.LegalMoves), 7)
+	assert.Equals(t, message.Game.Seats["red"], BOT)
+	receive(t, watcher)
+
+	// illegal moves and moves out of turn are refused
+	websocket.JSON.Send(black, clientMessage{Type: "move", Move: "11-18"})
+	message = receive(t, black)
+	assert.Equals(t, message.Type, "error")
+	websocket.JSON.Send(watcher, clientMessage{Type: "move", Move: "11-15"})
+	message = receive(t, watcher)
+	assert.Equals(t, message.Error, "not your turn")
+
+	// the human's move is followed by the bot's reply
+	websocket.JSON.Send(black, clientMessage{Type: "move", Move: "11-15"})
+	message = receive(t, black)
+	assert.Equals(t, message.Game.Moves, []string{"11-15"})
+	assert.Equals(t, message.Game.Player.String(), "red")
+	message = receive(t, black)
+	assert.Equals(t, len(message.Game.Moves), 2)
+	assert.Equals(t, message.Game.Player.String(), "black")
+
+	receive(t, watcher)
+	message = receive(t, watcher)
+	assert.Equals(t, len(message.Game.Moves), 2)
+
+}
+
+func TestBotVersusBot(t *testing.T) {
+
+	server := httptest.NewServer(newGameServer())
+	defer server.Close()
+
+	// red can only move into a capture, then black has won
+	id := createGame(t, server.URL, `{"black": "bot", "red": "bot", "depth": 1, "fen": "W:W32:B28,K23"}`)
+	watcher := connect(t, server.URL, id, "watch")
+	defer watcher.Close()
+
+	message := receive(t, watcher)
+	for !message.Game.Over {
+		message = receive(t, watcher)
+	}
+	assert.Equals(t, message.Game.Result, "black wins")
+
+}
+
+func TestFinishedGamesRemoved(t *testing.T) {
+
+	gs := newGameServer()
+	gs.finishedTTL = 200 * time.Millisecond
+	server := httptest.NewServer(gs)
+	defer server.Close()
+
+	id := createGame(t, server.URL, `{"black": "bot", "red": "bot", "depth": 1, "fen": "W:W32:B28,K23"}`)
+	watcher := connect(t, server.URL, id, "watch")
+	defer watcher.Close()
+
+	// the watcher is disconnected once the finished game is removed
+	message := serverMessage{}
+	for websocket.JSON.Receive(watcher, &message) == nil {
+	}
+	assert.True(t, message.Game.Over)
+	assert.True(t, gs.session(id) == nil)
+
+	// and nobody can join it afterwards
+	s, c := gs.join(id, "watch")
+	assert.True(t, s == nil && c == nil)
+
+}
+
+func TestCreateGameErrors(t *testing.T) {
+
+	server := httptest.NewServer(newGameServer())
+	defer server.Close()
+
+	for _, body := range []string{
+		`{"black": "alien"}`,
+		`{"depth": 100}`,
+		`{"fen": "nonsense"}`,
+		`{"fen": "B:W1:B5"}`,
+		`not json`,
+	} {
+		resp, err := http.Post(server.URL+"/games", "application/json", strings.NewReader(body))
+		assert.True(t, err == nil)
+		resp.Body.Close()
+		assert.Equals(t, resp.StatusCode, http.StatusBadRequest)
+	}
+
+	resp, err := http.Get(server.URL + "/games/42/ws")
+	assert.True(t, err == nil)
+	resp.Body.Close()
+	assert.Equals(t, resp.StatusCode, http.StatusNotFound)
+
+}
+
+func jsonDecode(resp *http.Response, value interface{}) error {
+	return json.NewDecoder(resp.Body).Decode(value)
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"sync"
+
+	core "github.com/tleyden/checkers-core"
+)
+
+const (
+	HUMAN = "human"
+	BOT   = "bot"
+)
+
+// A single game, shared by the clients connected to it
+type session struct {
+	id     string
+	seats  map[core.Player]string
+	limits core.SearchLimits
+	eval   core.EvaluationFunction
+
+	mutex   sync.Mutex
+	game    *core.Game
+	clients map[*client]bool
+	botBusy bool
+	botDone sync.WaitGroup
+
+	// if not nil, called once the game is over
+	onFinished func()
+	finished   bool
+}
+
+// A connection to a session, sitting in a seat ("red" or "black") or
+// watching ("watch").
+type client struct {
+	seat string
+	send chan serverMessage
+}
+
+type clientMessage struct {
+	Type string `json:"type"`
+	Move string `json:"move,omitempty"`
+}
+
+type serverMessage struct {
+	Type  string     `json:"type"`
+	Error string     `json:"error,omitempty"`
+	Game  *gameState `json:"game,omitempty"`
+}
+
+type gameState struct {
+	ID         string            `json:"id"`
+	FEN        string            `json:"fen"`
+	Board      core.Board        `json:"board"`
+	Player     core.Player       `json:"player"`
+	Moves      []string          `json:"moves"`
+	LegalMoves []string          `json:"legal_moves"`
+	Result     string            `json:"result"`
+	Over       bool              `json:"over"`
+	Seats      map[string]string `json:"seats"`
+}
+
+func newSession(id string, start core.Position, seats map[core.Player]string, limits core.SearchLimits) *session {
+	return &session{
+		id:      id,
+		seats:   seats,
+		limits:  limits,
+		eval:    core.DefaultEvaluationFunction(),
+		game:    core.NewGameFromPosition(start),
+		clients: map[*client]bool{},
+	}
+}
+
+// Add a client, sending it the current state
+func (s *session) join(seat string) *client {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	c := &client{seat: seat, send: make(chan serverMessage, 16)}
+	s.clients[c] = true
+	c.send <- s.updateMessage()
+	return c
+}
+
+func (s *session) leave(c *client) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.drop(c)
+}
+
+// Disconnect a client, if it's still connected.  Must be called with the
+// mutex held.
+func (s *session) drop(c *client) {
+	if s.clients[c] {
+		delete(s.clients, c)
+		close(c.send)
+	}
+}
+
+// Send a message to a single client
+func (s *session) reply(c *client, message serverMessage) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.sendTo(c, message)
+}
+
+// Send a message to a client, if it's still connected, disconnecting it
+// if it isn't keeping up.  Must be called with the mutex held.
+func (s *session) sendTo(c *client, message serverMessage) {
+	if s.clients[c] && !c.trySend(message) {
+		s.drop(c)
+	}
+}
+
+// Play a move submitted by a client, if it's that client's turn
+func (s *session) submitMove(c *client, notation string) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	player := s.game.Position.Player
+	if c.seat != player.String() || s.seats[player] != HUMAN {
+		s.sendTo(c, serverMessage{Type: "error", Error: "not your turn"})
+		return
+	}
+	if _, err := s.game.PlayNotation(notation); err != nil {
+		s.sendTo(c, serverMessage{Type: "error", Error: err.Error()})
+		return
+	}
+	s.broadcast()
+	s.checkFinished()
+	s.startBotIfNeeded()
+
+}
+
+// Call onFinished if the game has just ended.  Must be called with the
+// mutex held.
+func (s *session) checkFinished() {
+	if s.finished || !s.game.IsOver() {
+		return
+	}
+	s.finished = true
+	if s.onFinished != nil {
+		s.onFinished()
+	}
+}
+
+// Disconnect every client, and wait for any bot search to finish
+func (s *session) close() {
+	s.mutex.Lock()
+	for c := range s.clients {
+		s.drop(c)
+	}
+	s.mutex.Unlock()
+	s.botDone.Wait()
+}
+
+// If it's a bot's turn, search for its move in the background.  Must be
+// called with the mutex held.
+func (s *session) startBotIfNeeded() {
+
+	player := s.game.Position.Player
+	if s.botBusy || s.game.IsOver() || s.seats[player] != BOT {
+		return
+	}
+	s.botBusy = true
+	position := s.game.Position
+	ply := len(s.game.Moves)
+
+	s.botDone.Add(1)
+	go func() {
+		defer s.botDone.Done()
+		info := position.Board.Search(position.Player, s.limits, s.eval, nil)
+		move, _ := info.BestMove()
+
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		s.botBusy = false
+		// ignore the result if the game has moved on
+		if len(s.game.Moves) == ply && s.game.Play(move) == nil {
+			s.broadcast()
+			s.checkFinished()
+		}
+		s.startBotIfNeeded()
+	}()
+
+}
+
+// Send the current state to every client.  Clients that aren't keeping
+// up are disconnected.  Must be called with the mutex held.
+func (s *session) broadcast() {
+	message := s.updateMessage()
+	for c := range s.clients {
+		s.sendTo(c, message)
+	}
+}
+
+func (s *session) updateMessage() serverMessage {
+	game := s.game
+	state := &gameState{
+		ID:         s.id,
+		FEN:        game.Position.FEN(),
+		Board:      game.Position.Board,
+		Player:     game.Position.Player,
+		Moves:      notations(game.Moves),
+		LegalMoves: notations(game.LegalMoves()),
+		Result:     game.Result().String(),
+		Over:       game.IsOver(),
+		Seats:      map[string]string{},
+	}
+	for player, seat := range s.seats {
+		state.Seats[player.String()] = seat
+	}
+	return serverMessage{Type: "update", Game: state}
+}
+
+func (c *client) trySend(message serverMessage) bool {
+	select {
+	case c.send <- message:
+		return true
+	default:
+		return false
+	}
+}
+
+func notations(moves []core.Move) []string {
+	result := []string{}
+	for _, move := range moves {
+		result = append(result, move.Notation())
+	}
+	return result
+}
@@ -0,0 +1,390 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	core "github.com/tleyden/checkers-core"
+)
+
+type hub struct {
+	position core.Position
+	limits   core.SearchLimits
+	eval     core.EvaluationFunction
+
+	out        io.Writer
+	outMutex   sync.Mutex
+	stop       chan struct{}
+	searchDone sync.WaitGroup
+}
+
+// A parsed message, eg `pos pos=We... moves="32-28"`
+type hubMessage struct {
+	command string
+	args    map[string]string
+}
+
+func newHub(out io.Writer) *hub {
+	return &hub{
+		position: core.NewStartingPosition(),
+		limits:   core.SearchLimits{Depth: 8},
+		eval:     core.DefaultEvaluationFunction(),
+		out:      out,
+	}
+}
+
+// Handle messages from in until it's exhausted or "quit" is received
+func (h *hub) run(in io.Reader) error {
+	defer h.stopSearch()
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		if !h.handle(scanner.Text()) {
+			return nil
+		}
+	}
+	return scanner.Err()
+}
+
+// Handle a single message, returning false if the engine should exit
+func (h *hub) handle(line string) bool {
+
+	message, err := parseHubMessage(line)
+	if err != nil {
+		h.send("error message=%q", err.Error())
+		return true
+	}
+
+	switch message.command {
+	case "":
+	case "hub":
+		h.send("id name=checkers-core version=1.0")
+		h.send("wait")
+	case "init":
+		h.send("ready")
+	case "ping":
+		h.send("pong")
+	case "new-game":
+		h.stopSearch()
+		h.position = core.NewStartingPosition()
+	case "pos":
+		h.stopSearch()
+		err = h.setPosition(message.args)
+	case "level":
+		err = h.setLevel(message.args)
+	case "go":
+		h.stopSearch()
+		h.startSearch(message.has("analyze"))
+	case "stop":
+		h.stopSearch()
+	case "ponder-hit", "set-param":
+		// pondering and parameters are not supported
+	case "quit":
+		return false
+	default:
+		err = fmt.Errorf("unknown message: %v", message.command)
+	}
+
+	if err != nil {
+		h.send("error message=%q", err.Error())
+	}
+	return true
+
+}
+
+func (h *hub) setPosition(args map[string]string) error {
+
+	position := core.NewStartingPosition()
+	if posStr, ok := args["pos"]; ok {
+		var err error
+		position, err = parseHubPosition(posStr)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, moveStr := range strings.Fields(args["moves"]) {
+		move, err := parseHubMove(position, moveStr)
+		if err != nil {
+			return err
+		}
+		position = position.ApplyMove(move)
+	}
+
+	h.position = position
+	return nil
+
+}
+
+func (h *hub) setLevel(args map[string]string) error {
+
+	limits := core.SearchLimits{}
+	switch {
+	case has(args, "infinite"):
+	case has(args, "depth"):
+		depth, err := strconv.Atoi(args["depth"])
+		if err != nil || depth < 1 {
+			return fmt.Errorf("invalid depth: %v", args["depth"])
+		}
+		limits.Depth = depth
+	case has(args, "move-time"):
+		moveTime, err := parseSeconds(args["move-time"])
+		if err != nil {
+			return err
+		}
+		limits.MoveTime = moveTime
+	case has(args, "time"):
+		// spread the remaining time over the moves left in the time
+		// control, or a nominal 30 moves, plus the increment
+		remaining, err := parseSeconds(args["time"])
+		if err != nil {
+			return err
+		}
+		movesLeft := 30
+		if has(args, "moves") {
+			movesLeft, err = strconv.Atoi(args["moves"])
+			if err != nil || movesLeft < 1 {
+				return fmt.Errorf("invalid moves: %v", args["moves"])
+			}
+		}
+		increment := time.Duration(0)
+		if has(args, "inc") {
+			increment, err = parseSeconds(args["inc"])
+			if err != nil {
+				return err
+			}
+		}
+		limits.MoveTime = remaining/time.Duration(movesLeft) + increment
+	default:
+		return fmt.Errorf("unsupported level")
+	}
+	h.limits = limits
+	return nil
+
+}
+
+func (h *hub) startSearch(analyze bool) {
+
+	limits := h.limits
+	if analyze {
+		limits = core.SearchLimits{}
+	}
+	limits.Stop = make(chan struct{})
+	h.stop = limits.Stop
+	position := h.position
+
+	progress := func(info core.SearchInfo) {
+		h.send(
+			"info depth=%d score=%.2f nodes=%d time=%.3f pv=%q",
+			info.Depth,
+			info.Score,
+			info.Nodes,
+			info.Elapsed.Seconds(),
+			hubMoves(info.PV),
+		)
+	}
+
+	h.searchDone.Add(1)
+	go func() {
+		defer h.searchDone.Done()
+		info := position.Board.Search(position.Player, limits, h.eval, progress)
+		h.sendDone(info)
+	}()
+
+}
+
+// Stop the current search, if any, and wait for it to finish
+func (h *hub) stopSearch() {
+	if h.stop != nil {
+		close(h.stop)
+		h.stop = nil
+	}
+	h.searchDone.Wait()
+}
+
+func (h *hub) sendDone(info core.SearchInfo) {
+	bestMove, ok := info.BestMove()
+	if !ok {
+		h.send("done")
+		return
+	}
+	if len(info.PV) > 1 {
+		h.send("done move=%v ponder=%v", hubMove(bestMove), hubMove(info.PV[1]))
+		return
+	}
+	h.send("done move=%v", hubMove(bestMove))
+}
+
+func (h *hub) send(format string, args ...interface{}) {
+	h.outMutex.Lock()
+	defer h.outMutex.Unlock()
+	fmt.Fprintf(h.out, format+"\n", args...)
+}
+
+func (message hubMessage) has(name string) bool {
+	return has(message.args, name)
+}
+
+func has(args map[string]string, name string) bool {
+	_, ok := args[name]
+	return ok
+}
+
+// Parse a message such as `pos pos=We... moves="32-28 19-23"`.  Bare
+// words after the command are stored as arguments with empty values.
+func parseHubMessage(line string) (hubMessage, error) {
+
+	message := hubMessage{args: map[string]string{}}
+	tokens := []string{}
+	current := bytes.Buffer{}
+	inQuotes := false
+	for _, r := range line {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+		case (r == ' ' || r == '\t') && !inQuotes:
+			if current.Len() > 0 {
+				tokens = append(tokens, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if inQuotes {
+		return message, fmt.Errorf("unterminated quote")
+	}
+	if current.Len() > 0 {
+		tokens = append(tokens, current.String())
+	}
+	if len(tokens) == 0 {
+		return message, nil
+	}
+
+	message.command = tokens[0]
+	for _, token := range tokens[1:] {
+		fields := strings.SplitN(token, "=", 2)
+		if len(fields) == 2 {
+			message.args[fields[0]] = fields[1]
+		} else {
+			message.args[fields[0]] = ""
+		}
+	}
+	return message, nil
+
+}
+
+func parseHubPosition(posStr string) (core.Position, error) {
+
+	position := core.Position{Board: core.NewEmptyBoard()}
+	if len(posStr) == 51 {
+		return position, fmt.Errorf("10x10 positions are not supported: %v", posStr)
+	}
+	if len(posStr) != 33 {
+		return position, fmt.Errorf("position must have 33 characters: %v", posStr)
+	}
+	switch posStr[0] {
+	case 'W':
+		position.Player = core.RED_PLAYER
+	case 'B':
+		position.Player = core.BLACK_PLAYER
+	default:
+		return position, fmt.Errorf("invalid side to move: %c", posStr[0])
+	}
+
+	for i, square := range posStr[1:] {
+		var piece core.Piece
+		switch square {
+		case 'e':
+			continue
+		case 'w':
+			piece = core.RED
+		case 'W':
+			piece = core.RED_KING
+		case 'b':
+			piece = core.BLACK
+		case 'B':
+			piece = core.BLACK_KING
+		default:
+			return position, fmt.Errorf("invalid square: %c", square)
+		}
+		loc, _ := core.NewLocationFromSquareNumber(i + 1)
+		position.Board[loc.Row()][loc.Col()] = piece
+	}
+	return position, nil
+
+}
+
+// Find the legal move matching a Hub move, where captures list the
+// from and to squares followed by the captured squares in any order.
+func parseHubMove(position core.Position, moveStr string) (core.Move, error) {
+	for _, move := range position.LegalMoves() {
+		if hubMove(move) == canonicalHubMove(moveStr) {
+			return move, nil
+		}
+	}
+	return core.Move{}, fmt.Errorf("illegal move: %v", moveStr)
+}
+
+func hubMove(move core.Move) string {
+	if !move.IsJump() {
+		return move.Notation()
+	}
+	squares := []string{
+		strconv.Itoa(move.From().SquareNumber()),
+		strconv.Itoa(move.To().SquareNumber()),
+	}
+	captured := []int{}
+	for _, loc := range move.Captured() {
+		captured = append(captured, loc.SquareNumber())
+	}
+	sort.Ints(captured)
+	for _, square := range captured {
+		squares = append(squares, strconv.Itoa(square))
+	}
+	return strings.Join(squares, "x")
+}
+
+// Sort the captured squares of a Hub capture so it can be compared
+func canonicalHubMove(moveStr string) string {
+	squares := strings.Split(moveStr, "x")
+	if len(squares) <= 2 {
+		return moveStr
+	}
+	captured := []int{}
+	for _, square := range squares[2:] {
+		number, err := strconv.Atoi(square)
+		if err != nil {
+			return moveStr
+		}
+		captured = append(captured, number)
+	}
+	sort.Ints(captured)
+	result := squares[:2]
+	for _, number := range captured {
+		result = append(result, strconv.Itoa(number))
+	}
+	return strings.Join(result, "x")
+}
+
+func hubMoves(moves []core.Move) string {
+	result := []string{}
+	for _, move := range moves {
+		result = append(result, hubMove(move))
+	}
+	return strings.Join(result, " ")
+}
+
+func parseSeconds(str string) (time.Duration, error) {
+	seconds, err := strconv.ParseFloat(str, 64)
+	if err != nil || seconds < 0 {
+		return 0, fmt.Errorf("invalid time: %v", str)
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bytes"
+	"github.com/couchbaselabs/go.assert"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	core "github.com/tleyden/checkers-core"
+)
+
+// An io.Writer that's safe to read while the search goroutine writes
+type syncBuffer struct {
+	mutex  sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.buffer.Write(p)
+}
+
+func (b *syncBuffer) lines() []string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return strings.Split(strings.TrimSpace(b.buffer.String()), "\n")
+}
+
+func TestParseHubMessage(t *testing.T) {
+
+	message, err := parseHubMessage(`pos pos=Wbbbbbbbbbbbbeeeeeeeewwwwwwwwwwww moves="22-18 11-15"`)
+	assert.True(t, err == nil)
+	assert.Equals(t, message.command, "pos")
+	assert.Equals(t, message.args["moves"], "22-18 11-15")
+	assert.True(t, message.has("pos"))
+
+	message, err = parseHubMessage("go think")
+	assert.True(t, err == nil)
+	assert.True(t, message.has("think"))
+	assert.False(t, message.has("analyze"))
+
+	_, err = parseHubMessage(`pos moves="22-18`)
+	assert.True(t, err != nil)
+
+}
+
+func TestParseHubPosition(t *testing.T) {
+
+	position, err := parseHubPosition("Bbbbbbbbbbbbbeeeeeeeewwwwwwwwwwww")
+	assert.True(t, err == nil)
+	assert.True(t, position == core.NewStartingPosition())
+
+	_, err = parseHubPosition("Bbbbb")
+	assert.True(t, err != nil)
+	_, err = parseHubPosition("Xbbbbbbbbbbbbeeeeeeeewwwwwwwwwwww")
+	assert.True(t, err != nil)
+
+	// the standard 10x10 Hub positions
+	_, err = parseHubPosition("W" + strings.Repeat("b", 20) + strings.Repeat("e", 10) + strings.Repeat("w", 20))
+	assert.True(t, err != nil)
+
+}
+
+func TestHubMoves(t *testing.T) {
+
+	position := core.NewStartingPosition()
+	move, err := parseHubMove(position, "11-15")
+	assert.True(t, err == nil)
+	assert.Equals(t, hubMove(move), "11-15")
+
+	// the captured squares may be given in any order
+	position, _ = core.ParseFEN("W:W30:B26,19")
+	move, err = parseHubMove(position, "30x16x26x19")
+	assert.True(t, err == nil)
+	assert.Equals(t, move.Notation(), "30x23x16")
+	assert.Equals(t, hubMove(move), "30x16x19x26")
+
+	_, err = parseHubMove(position, "30x16x19x22")
+	assert.True(t, err != nil)
+
+}
+
+func TestHubSession(t *testing.T) {
+
+	out := &syncBuffer{}
+	h := newHub(out)
+	messages := []string{
+		"hub",
+		"init",
+		"ping",
+		"level depth=3",
+		`pos moves="11-15 23-19"`,
+		"go think",
+	}
+	for _, message := range messages {
+		assert.True(t, h.handle(message))
+	}
+	h.searchDone.Wait()
+
+	lines := out.lines()
+	assert.True(t, strings.HasPrefix(lines[0], "id name="))
+	assert.Equals(t, lines[1], "wait")
+	assert.Equals(t, lines[2], "ready")
+	assert.Equals(t, lines[3], "pong")
+	assert.True(t, strings.HasPrefix(lines[4], "info depth=1 "))
+	assert.True(t, strings.HasPrefix(lines[len(lines)-1], "done move="))
+	assert.False(t, h.handle("quit"))
+
+}
+
+func TestHubStop(t *testing.T) {
+
+	out := &syncBuffer{}
+	h := newHub(out)
+	assert.True(t, h.handle("level infinite"))
+	assert.True(t, h.handle("go think"))
+	time.Sleep(20 * time.Millisecond)
+
+	stopped := make(chan bool)
+	go func() {
+		stopped <- h.handle("stop")
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("search did not stop")
+	}
+
+	lines := out.lines()
+	assert.True(t, strings.HasPrefix(lines[len(lines)-1], "done move="))
+
+}
+
+func TestHubErrors(t *testing.T) {
+
+	out := &syncBuffer{}
+	h := newHub(out)
+	messages := []string{
+		"bogus",
+		"level depth=zero",
+		"level move-time=-1",
+		"level time=60 moves=0",
+		"level nodes=1000",
+		"pos pos=Wbbb",
+		`pos moves="11-16 11-15"`,
+	}
+	for _, message := range messages {
+		assert.True(t, h.handle(message))
+	}
+	lines := out.lines()
+	assert.Equals(t, len(lines), len(messages))
+	for _, line := range lines {
+		assert.True(t, strings.HasPrefix(line, "error message="))
+	}
+
+	assert.True(t, h.handle("level time=60 inc=1"))
+	assert.Equals(t, h.limits.MoveTime, 3*time.Second)
+
+}
@@ -0,0 +1,44 @@
+/*
+A checkers engine speaking an 8x8 dialect of the Hub protocol used by
+draughts GUIs and tournament harnesses, over stdin/stdout.
+
+The standard Hub protocol, as spoken by Scan and the GUIs built for it,
+is for international draughts on a 10x10 board, with 51 character
+positions.  This engine plays English checkers, so it only understands
+the 33 character positions described below, and won't work with GUIs
+that send 10x10 positions.
+
+Supported messages from the GUI:
+
+	hub                               replies with id and "wait"
+	init                              replies "ready"
+	ping                              replies "pong"
+	new-game                          resets to the starting position
+	pos [pos=<pos>] [moves="<m> ..."] sets the position
+	level depth=<n> | move-time=<s> | time=<s> [inc=<s>] [moves=<n>] | infinite
+	go think | go analyze             starts searching
+	stop                              stops the current search
+	quit                              exits
+
+Positions are a side to move ("W" or "B") followed by one character for
+each of the 32 squares: "w" or "b" for men, "W" or "B" for kings and "e"
+for empty squares.  White refers to the red pieces, which start on squares
+21-32.  Moves are written "11-15", and captures as the from and to
+squares followed by the captured squares, eg "15x24x19".
+
+While searching the engine sends "info" messages, and when done:
+
+	done move=<m> [ponder=<m>]
+*/
+package main
+
+import (
+	"os"
+)
+
+func main() {
+	h := newHub(os.Stdout)
+	if err := h.run(os.Stdin); err != nil {
+		os.Exit(1)
+	}
+}
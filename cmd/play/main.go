@@ -0,0 +1,55 @@
+/*
+Play a game of checkers against the engine in the terminal.
+
+Moves are entered in standard notation, eg "11-15" or "15x24", and the
+full path may be given to pick between multiple jumps, eg "1x10x19".
+The other commands are:
+
+	moves   list the legal moves
+	undo    take back your last move (and the engine's reply)
+	help    show the commands
+	quit    leave the game
+
+Black moves first.  The board is drawn from your side, with your pieces
+at the bottom and the last move highlighted.
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	core "github.com/tleyden/checkers-core"
+)
+
+func main() {
+
+	depth := flag.Int("depth", 6, "engine search depth")
+	color := flag.String("color", "black", "the color you play, black or red")
+	unicode := flag.Bool("unicode", false, "draw pieces with unicode glyphs")
+	ansi := flag.Bool("ansi", true, "draw the board with ANSI colors")
+	flag.Parse()
+
+	p := newPlayer(os.Stdin, os.Stdout)
+	p.limits = core.SearchLimits{Depth: *depth}
+	if *unicode {
+		p.render.Style = core.UNICODE_STYLE
+	}
+	p.render.Color = *ansi
+	switch *color {
+	case "black":
+		p.human = core.BLACK_PLAYER
+	case "red":
+		p.human = core.RED_PLAYER
+	default:
+		fmt.Fprintf(os.Stderr, "invalid color: %v\n", *color)
+		os.Exit(2)
+	}
+
+	if err := p.run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+}
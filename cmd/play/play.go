@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	core "github.com/tleyden/checkers-core"
+)
+
+const helpText = `enter a move such as 11-15 or 15x24, or one of:
+  moves   list the legal moves
+  undo    take back your last move
+  help    show this message
+  quit    leave the game`
+
+// A game between a human at the terminal and the engine
+type player struct {
+	game   *core.Game
+	human  core.Player
+	limits core.SearchLimits
+	eval   core.EvaluationFunction
+	render core.RenderOptions
+	in     *bufio.Scanner
+	out    io.Writer
+}
+
+func newPlayer(in io.Reader, out io.Writer) *player {
+	return &player{
+		game:   core.NewGame(),
+		human:  core.BLACK_PLAYER,
+		limits: core.SearchLimits{Depth: 6},
+		eval:   core.DefaultEvaluationFunction(),
+		render: core.RenderOptions{SquareNumbers: true},
+		in:     bufio.NewScanner(in),
+		out:    out,
+	}
+}
+
+// Play until the game ends, the human quits or the input is exhausted
+func (p *player) run() error {
+
+	for !p.game.IsOver() {
+		if p.game.Position.Player != p.human {
+			p.engineMove()
+			continue
+		}
+		p.show()
+		fmt.Fprint(p.out, "> ")
+		if !p.in.Scan() {
+			return p.in.Err()
+		}
+		if !p.handle(p.in.Text()) {
+			return nil
+		}
+	}
+
+	p.show()
+	fmt.Fprintf(p.out, "game over: %v\n", p.game.Result())
+	return nil
+
+}
+
+// Handle a line of input, returning false if the human has quit
+func (p *player) handle(line string) bool {
+
+	line = strings.TrimSpace(line)
+	switch line {
+	case "":
+	case "moves":
+		fmt.Fprintf(p.out, "legal moves: %v\n", strings.Join(notations(p.game.LegalMoves()), " "))
+	case "undo":
+		p.undo()
+	case "help":
+		fmt.Fprintln(p.out, helpText)
+	case "quit":
+		return false
+	default:
+		if _, err := p.game.PlayNotation(line); err != nil {
+			fmt.Fprintf(p.out, "%v (type help for the commands)\n", err)
+		}
+	}
+	return true
+
+}
+
+func (p *player) engineMove() {
+	position := p.game.Position
+	info := position.Board.Search(position.Player, p.limits, p.eval, nil)
+	move, _ := info.BestMove()
+	p.game.Play(move)
+	fmt.Fprintf(p.out, "engine plays %v\n", move.Notation())
+}
+
+// Take back moves until the human's last move has been undone
+func (p *player) undo() {
+	for i := len(p.game.Moves) - 1; i >= 0; i-- {
+		if i%2 == 0 && p.game.Start.Player == p.human || i%2 == 1 && p.game.Start.Player != p.human {
+			for len(p.game.Moves) > i {
+				p.game.Undo()
+			}
+			return
+		}
+	}
+	fmt.Fprintln(p.out, "nothing to undo")
+}
+
+// Draw the board from the human's side, highlighting the last move
+func (p *player) show() {
+	options := p.render
+	options.Flipped = p.human == core.BLACK_PLAYER
+	if len(p.game.Moves) > 0 {
+		last := p.game.Moves[len(p.game.Moves)-1]
+		options.Highlight = &last
+	}
+	fmt.Fprint(p.out, p.game.Position.Board.Render(options))
+	if options.Highlight != nil {
+		fmt.Fprintf(p.out, "last move: %v\n", options.Highlight.Notation())
+	}
+	fmt.Fprintf(p.out, "%v to move\n", p.game.Position.Player)
+}
+
+func notations(moves []core.Move) []string {
+	result := []string{}
+	for _, move := range moves {
+		result = append(result, move.Notation())
+	}
+	return result
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/couchbaselabs/go.assert"
+	core "github.com/tleyden/checkers-core"
+)
+
+func runPlayer(t *testing.T, p *player, input string) string {
+	out := bytes.Buffer{}
+	p.in = newPlayer(strings.NewReader(input), &out).in
+	p.out = &out
+	assert.True(t, p.run() == nil)
+	return out.String()
+}
+
+func TestPlayMovesAndUndo(t *testing.T) {
+
+	p := newPlayer(nil, nil)
+	p.limits = core.SearchLimits{Depth: 1}
+	output := runPlayer(t, p, "moves\n11-16x\n11-15\nundo\nundo\n9-13\nquit\n")
+
+	assert.True(t, strings.Contains(output, "legal moves: 9-14 9-13 10-15 10-14 11-16 11-15 12-16"))
+	assert.True(t, strings.Contains(output, "(type help for the commands)"))
+	assert.Equals(t, strings.Count(output, "engine plays "), 2)
+	assert.True(t, strings.Contains(output, "nothing to undo"))
+	assert.Equals(t, len(p.game.Moves), 2)
+	assert.Equals(t, p.game.Moves[0].Notation(), "9-13")
+
+}
+
+func TestPlayUntilGameOver(t *testing.T) {
+
+	// the engine, playing red, must move into black's capture
+	start, _ := core.ParseFEN("W:W32:B28,K23")
+	p := newPlayer(nil, nil)
+	p.game = core.NewGameFromPosition(start)
+	output := runPlayer(t, p, "moves\n")
+	assert.True(t, strings.Contains(output, "engine plays 32-27"))
+	assert.True(t, strings.Contains(output, "legal moves: 23x32"))
+
+	output = runPlayer(t, p, "23x32\n")
+	assert.True(t, strings.HasSuffix(output, "game over: black wins\n"))
+
+}
+
+func TestPlayShow(t *testing.T) {
+
+	out := bytes.Buffer{}
+	p := newPlayer(nil, &out)
+	p.game.PlayNotation("11-15")
+	p.show()
+	lines := strings.Split(out.String(), "\n")
+
+	// black plays from the bottom of the board
+	assert.Equals(t, lines[0], "    x     x     x     x    32 31 30 29")
+	assert.Equals(t, lines[4], "    .    [o]    .     .    16 15 14 13")
+	assert.Equals(t, lines[8], "last move: 11-15")
+	assert.Equals(t, lines[9], "red to move")
+
+}
@@ -0,0 +1,108 @@
+/*
+Play games of the engine against itself to generate training data.
+
+Games are played in parallel and written to <out>/games.pdn, and every
+position in which a move was searched is written as a line of JSON to
+<out>/positions.jsonl:
+
+	{"fen": "B:W18,24,...:B1,2,...", "score": 1.3, "outcome": 1}
+
+The score is the search score and the outcome the final result (1 for a
+win, 0.5 for a draw and 0 for a loss), both from the point of view of
+the player to move.
+
+Each game opens with some random moves, or a random three-move ballot
+drawn from every three-move opening or from a deck file such as the ACF
+one, in the format read by checkerscore.ReadBallots.  For the first few
+searched moves the move is picked at random with probabilities given by
+a softmax of the move scores over the temperature.  Games still going
+after the maximum number of plies are drawn.
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	core "github.com/tleyden/checkers-core"
+)
+
+func main() {
+
+	cfg := defaultConfig()
+	flag.IntVar(&cfg.games, "games", cfg.games, "number of games to play")
+	flag.IntVar(&cfg.parallel, "parallel", runtime.NumCPU(), "number of games to play at once")
+	blackDepth := flag.Int("black-depth", 6, "search depth for black")
+	redDepth := flag.Int("red-depth", 6, "search depth for red")
+	moveTime := flag.Duration("movetime", 0, "search time limit per move for both players")
+	flag.IntVar(&cfg.randomPlies, "random-plies", cfg.randomPlies, "number of random opening moves")
+	ballots := flag.Bool("ballots", false, "open with a random three-move ballot instead of random moves")
+	deck := flag.String("deck", "", "file of ballots to open with, rather than every three-move opening")
+	flag.Float64Var(&cfg.temperature, "temperature", cfg.temperature, "softmax temperature for move selection, or 0 to always play the best move")
+	flag.IntVar(&cfg.temperaturePlies, "temperature-plies", cfg.temperaturePlies, "number of searched moves selected using the temperature")
+	flag.IntVar(&cfg.maxPlies, "max-plies", cfg.maxPlies, "number of plies after which the game is drawn")
+	flag.Int64Var(&cfg.seed, "seed", time.Now().UnixNano(), "random seed")
+	out := flag.String("out", "selfplay", "output directory")
+	flag.Parse()
+
+	cfg.limits[core.BLACK_PLAYER] = core.SearchLimits{Depth: *blackDepth, MoveTime: *moveTime}
+	cfg.limits[core.RED_PLAYER] = core.SearchLimits{Depth: *redDepth, MoveTime: *moveTime}
+	switch {
+	case *deck != "":
+		var err error
+		if cfg.ballots, err = readDeck(*deck); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	case *ballots:
+		cfg.ballots = core.ThreeMoveBallots()
+	}
+
+	if err := writeGames(cfg, *out); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+}
+
+func writeGames(cfg config, dir string) error {
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	pdnFile, err := os.Create(filepath.Join(dir, "games.pdn"))
+	if err != nil {
+		return err
+	}
+	defer pdnFile.Close()
+	recordsFile, err := os.Create(filepath.Join(dir, "positions.jsonl"))
+	if err != nil {
+		return err
+	}
+	defer recordsFile.Close()
+
+	return run(cfg, pdnFile, recordsFile)
+
+}
+
+func readDeck(path string) ([]core.Ballot, error) {
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	ballots, err := core.ReadBallots(file)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", path, err)
+	}
+	if len(ballots) == 0 {
+		return nil, fmt.Errorf("%v: no ballots", path)
+	}
+	return ballots, nil
+
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"math"
+	"math/rand"
+	"strconv"
+	"sync"
+	"time"
+
+	core "github.com/tleyden/checkers-core"
+)
+
+type config struct {
+	games            int
+	parallel         int
+	limits           map[core.Player]core.SearchLimits
+	eval             core.EvaluationFunction
+	randomPlies      int
+	ballots          []core.Ballot // the deck to open from, or nil for random moves
+	temperature      float64
+	temperaturePlies int
+	maxPlies         int
+	seed             int64
+}
+
+// A position in which a move was searched
+type record struct {
+	FEN     string  `json:"fen"`
+	Score   float64 `json:"score"`
+	Outcome float64 `json:"outcome"`
+}
+
+type playedGame struct {
+	pdn     core.PDNGame
+	records []record
+}
+
+func defaultConfig() config {
+	return config{
+		games:    100,
+		parallel: 1,
+		limits: map[core.Player]core.SearchLimits{
+			core.BLACK_PLAYER: {Depth: 6},
+			core.RED_PLAYER:   {Depth: 6},
+		},
+		eval:             core.DefaultEvaluationFunction(),
+		randomPlies:      4,
+		temperature:      0.5,
+		temperaturePlies: 10,
+		maxPlies:         200,
+	}
+}
+
+// Play the games, writing each to the outputs as soon as it finishes
+func run(cfg config, pdnOut, recordsOut io.Writer) error {
+
+	records := bufio.NewWriter(recordsOut)
+	encoder := json.NewEncoder(records)
+
+	var mutex sync.Mutex
+	var err error
+	write := func(game playedGame) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if err != nil {
+			return
+		}
+		if err = core.WritePDN(pdnOut, []core.PDNGame{game.pdn}); err != nil {
+			return
+		}
+		for _, r := range game.records {
+			if err = encoder.Encode(r); err != nil {
+				return
+			}
+		}
+	}
+
+	indexes := make(chan int)
+	wg := sync.WaitGroup{}
+	for worker := 0; worker < cfg.parallel; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				// seeded per game, so the games don't depend on scheduling
+				rng := rand.New(rand.NewSource(cfg.seed + int64(i)))
+				game := playGame(cfg, rng)
+				game.pdn.Tags["Round"] = strconv.Itoa(i + 1)
+				write(game)
+			}
+		}()
+	}
+	for i := 0; i < cfg.games; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	if err != nil {
+		return err
+	}
+	return records.Flush()
+
+}
+
+func playGame(cfg config, rng *rand.Rand) playedGame {
+
+	game := core.NewGame()
+	if cfg.ballots != nil {
+		for _, notation := range core.RandomBallot(cfg.ballots, rng).Moves {
+			game.PlayNotation(notation)
+		}
+	} else {
+		for ply := 0; ply < cfg.randomPlies && !game.IsOver(); ply++ {
+			moves := game.LegalMoves()
+			game.Play(moves[rng.Intn(len(moves))])
+		}
+	}
+
+	searched := []record{}
+	players := []core.Player{}
+	for !game.IsOver() && len(game.Moves) < cfg.maxPlies {
+		position := game.Position
+		move, score := selectMove(cfg, position, len(searched) < cfg.temperaturePlies, rng)
+		searched = append(searched, record{FEN: position.FEN(), Score: score})
+		players = append(players, position.Player)
+		game.Play(move)
+	}
+
+	result := game.Result()
+	if result == core.UNKNOWN_RESULT {
+		result = core.DRAW
+	}
+	for i := range searched {
+		searched[i].Outcome = result.ScoreFor(players[i])
+	}
+
+	pdn := core.PDNGame{
+		Tags: map[string]string{
+			"Event": "Self play",
+			"Black": searcherName(cfg.limits[core.BLACK_PLAYER]),
+			"White": searcherName(cfg.limits[core.RED_PLAYER]),
+		},
+		Result: result,
+	}
+	for _, move := range game.Moves {
+		pdn.Moves = append(pdn.Moves, move.Notation())
+	}
+	return playedGame{pdn: pdn, records: searched}
+
+}
+
+// Search the position, returning the move to play and its search score.
+// With a temperature, every move is scored, sharing out any move time
+// between them, and one picked at random.
+func selectMove(cfg config, position core.Position, useTemperature bool, rng *rand.Rand) (core.Move, float64) {
+
+	limits := cfg.limits[position.Player]
+	if !useTemperature || cfg.temperature <= 0 {
+		info := position.Search(limits, cfg.eval, nil)
+		move, _ := info.BestMove()
+		return move, info.Score
+	}
+
+	if limits.Depth > 1 {
+		limits.Depth -= 1
+	}
+	moves := position.LegalMoves()
+	limits.MoveTime /= time.Duration(len(moves))
+	scores := []float64{}
+	best := math.Inf(-1)
+	for _, move := range moves {
+		info := position.ApplyMove(move).Search(limits, cfg.eval, nil)
+		score := -info.Score
+		scores = append(scores, score)
+		best = math.Max(best, score)
+	}
+
+	// softmax, relative to the best score to avoid overflow
+	weights := []float64{}
+	total := 0.0
+	for _, score := range scores {
+		weight := math.Exp((score - best) / cfg.temperature)
+		weights = append(weights, weight)
+		total += weight
+	}
+	choice := rng.Float64() * total
+	for i, weight := range weights {
+		choice -= weight
+		if choice < 0 {
+			return moves[i], scores[i]
+		}
+	}
+	return moves[len(moves)-1], scores[len(moves)-1]
+
+}
+
+func searcherName(limits core.SearchLimits) string {
+	if limits.MoveTime > 0 {
+		return "checkers-core movetime " + limits.MoveTime.String()
+	}
+	return "checkers-core depth " + strconv.Itoa(limits.Depth)
+}
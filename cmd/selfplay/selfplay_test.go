@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/couchbaselabs/go.assert"
+	core "github.com/tleyden/checkers-core"
+)
+
+func testConfig() config {
+	cfg := defaultConfig()
+	cfg.games = 6
+	cfg.parallel = 3
+	cfg.limits[core.BLACK_PLAYER] = core.SearchLimits{Depth: 2}
+	cfg.limits[core.RED_PLAYER] = core.SearchLimits{Depth: 1}
+	cfg.temperaturePlies = 4
+	cfg.maxPlies = 60
+	cfg.seed = 1
+	return cfg
+}
+
+func runSelfPlay(t *testing.T, cfg config) ([]core.PDNGame, []record) {
+
+	pdnOut, recordsOut := bytes.Buffer{}, bytes.Buffer{}
+	assert.True(t, run(cfg, &pdnOut, &recordsOut) == nil)
+
+	games, err := core.ReadPDN(&pdnOut)
+	assert.True(t, err == nil)
+	records := []record{}
+	scanner := bufio.NewScanner(&recordsOut)
+	for scanner.Scan() {
+		r := record{}
+		assert.True(t, json.Unmarshal(scanner.Bytes(), &r) == nil)
+		records = append(records, r)
+	}
+	return games, records
+
+}
+
+func TestSelfPlay(t *testing.T) {
+
+	cfg := testConfig()
+	games, records := runSelfPlay(t, cfg)
+	assert.Equals(t, len(games), cfg.games)
+
+	searchedPlies := 0
+	for _, game := range games {
+		assert.Equals(t, game.Tags["Black"], "checkers-core depth 2")
+		assert.True(t, game.Result != core.UNKNOWN_RESULT)
+		assert.True(t, len(game.Moves) <= cfg.maxPlies)
+		assert.True(t, game.Replay(nil) == nil)
+		searchedPlies += len(game.Moves) - cfg.randomPlies
+	}
+	assert.Equals(t, len(records), searchedPlies)
+
+	for _, r := range records {
+		position, err := core.ParseFEN(r.FEN)
+		assert.True(t, err == nil)
+		assert.True(t, len(position.LegalMoves()) > 0)
+		assert.True(t, r.Outcome == 0 || r.Outcome == 0.5 || r.Outcome == 1)
+	}
+
+}
+
+func TestSelfPlayIsReproducible(t *testing.T) {
+
+	cfg := testConfig()
+	cfg.ballots = core.ThreeMoveBallots()
+	first, _ := runSelfPlay(t, cfg)
+	cfg.parallel = 1
+	second, _ := runSelfPlay(t, cfg)
+
+	ballots := map[string]bool{}
+	for _, ballot := range core.ThreeMoveBallots() {
+		ballots[strings.Join(ballot.Moves, " ")] = true
+	}
+	rounds := map[string][]string{}
+	for _, game := range first {
+		rounds[game.Tags["Round"]] = game.Moves
+		assert.True(t, ballots[strings.Join(game.Moves[:3], " ")])
+	}
+	for _, game := range second {
+		assert.Equals(t, game.Moves, rounds[game.Tags["Round"]])
+	}
+
+}
+
+// With a temperature, the score recorded is that of the move chosen
+func TestSelectMoveScore(t *testing.T) {
+
+	cfg := testConfig()
+	cfg.temperature = 1000
+	// 9-14 and 11-15 lose a piece
+	position, _ := core.ParseFEN("B:W18:B9,11")
+	limits := cfg.limits[position.Player]
+	limits.Depth -= 1
+
+	chosen := map[string]bool{}
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		move, score := selectMove(cfg, position, true, rng)
+		chosen[move.Notation()] = true
+		info := position.ApplyMove(move).Search(limits, cfg.eval, nil)
+		assert.Equals(t, score, -info.Score)
+	}
+	assert.True(t, len(chosen) > 1)
+
+}
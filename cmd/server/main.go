@@ -0,0 +1,46 @@
+/*
+An HTTP server exposing the move generator, evaluation and search as a
+JSON API.  Every endpoint takes a POST with a JSON body describing the
+position, either as a PDN FEN:
+
+	{"fen": "B:W21-32:B1-12"}
+
+or as a compact board string and the player to move:
+
+	{"board": "|- o - o - o - o||o - o - o - o -|...", "player": "black"}
+
+Endpoints:
+
+	/legalmoves   {position}                       -> {"moves": [move, ...]}
+	/apply        {position, "move": "11-15"}      -> {position after the move}
+	/evaluate     {position}                       -> {"score": 0.0}
+	/search       {position, "depth": 6, "movetime_ms": 500}
+	              -> {"bestmove": move, "score": 0.3, "depth": 6, "nodes": 1234, "pv": [move, ...]}
+
+Positions are returned with "fen", "board" and "player" fields, and moves
+use the JSON encoding of checkerscore.Move.  Errors are returned with a
+400 status and {"error": "message"}.
+*/
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"time"
+)
+
+func main() {
+
+	addr := flag.String("addr", ":8080", "address to listen on")
+	maxDepth := flag.Int("max-depth", 12, "maximum search depth allowed")
+	maxMoveTime := flag.Duration("max-movetime", 10*time.Second, "maximum search time allowed")
+	flag.Parse()
+
+	s := newServer()
+	s.maxDepth = *maxDepth
+	s.maxMoveTime = *maxMoveTime
+	log.Printf("listening on %v", *addr)
+	log.Fatal(http.ListenAndServe(*addr, s))
+
+}
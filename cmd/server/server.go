@@ -0,0 +1,206 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	core "github.com/tleyden/checkers-core"
+)
+
+type server struct {
+	mux         *http.ServeMux
+	eval        core.EvaluationFunction
+	maxDepth    int
+	maxMoveTime time.Duration
+}
+
+type positionRequest struct {
+	FEN    string       `json:"fen,omitempty"`
+	Board  string       `json:"board,omitempty"`
+	Player *core.Player `json:"player,omitempty"`
+
+	// only used by some endpoints
+	Move       string `json:"move,omitempty"`
+	Depth      int    `json:"depth,omitempty"`
+	MoveTimeMs int    `json:"movetime_ms,omitempty"`
+}
+
+type positionResponse struct {
+	FEN    string      `json:"fen"`
+	Board  string      `json:"board"`
+	Player core.Player `json:"player"`
+}
+
+type searchResponse struct {
+	BestMove *core.Move  `json:"bestmove"`
+	Score    float64     `json:"score"`
+	Depth    int         `json:"depth"`
+	Nodes    int         `json:"nodes"`
+	PV       []core.Move `json:"pv"`
+}
+
+func newServer() *server {
+	s := &server{
+		mux:         http.NewServeMux(),
+		eval:        core.DefaultEvaluationFunction(),
+		maxDepth:    12,
+		maxMoveTime: 10 * time.Second,
+	}
+	s.handle("/legalmoves", s.legalMoves)
+	s.handle("/apply", s.apply)
+	s.handle("/evaluate", s.evaluate)
+	s.handle("/search", s.search)
+	return s
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// Register a JSON endpoint, taking care of decoding the request and
+// encoding the response or error.  The handler's context is cancelled if
+// the client goes away.
+func (s *server) handle(path string, handler func(ctx context.Context, request positionRequest, position core.Position) (interface{}, error)) {
+	s.mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+
+		if r.Method != "POST" {
+			writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "use POST"})
+			return
+		}
+
+		request := positionRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			writeError(w, fmt.Errorf("invalid JSON: %v", err))
+			return
+		}
+		position, err := request.position()
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		response, err := handler(r.Context(), request, position)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, response)
+
+	})
+}
+
+func (s *server) legalMoves(ctx context.Context, request positionRequest, position core.Position) (interface{}, error) {
+	return map[string][]core.Move{"moves": position.LegalMoves()}, nil
+}
+
+func (s *server) apply(ctx context.Context, request positionRequest, position core.Position) (interface{}, error) {
+	if request.Move == "" {
+		return nil, fmt.Errorf("move is required")
+	}
+	move, err := position.Board.ParseMove(position.Player, request.Move)
+	if err != nil {
+		return nil, err
+	}
+	return newPositionResponse(position.ApplyMove(move)), nil
+}
+
+func (s *server) evaluate(ctx context.Context, request positionRequest, position core.Position) (interface{}, error) {
+	score := s.eval(position.Player, position.Board)
+	return map[string]float64{"score": score}, nil
+}
+
+func (s *server) search(ctx context.Context, request positionRequest, position core.Position) (interface{}, error) {
+
+	limits := core.SearchLimits{
+		Depth:    request.Depth,
+		MoveTime: time.Duration(request.MoveTimeMs) * time.Millisecond,
+	}
+	if limits.Depth < 0 || limits.MoveTime < 0 {
+		return nil, fmt.Errorf("depth and movetime_ms must not be negative")
+	}
+	if limits.Depth == 0 || limits.Depth > s.maxDepth {
+		limits.Depth = s.maxDepth
+	}
+	if limits.MoveTime == 0 || limits.MoveTime > s.maxMoveTime {
+		limits.MoveTime = s.maxMoveTime
+	}
+
+	// stop searching if the client goes away
+	stop := make(chan struct{})
+	finished := make(chan struct{})
+	defer close(finished)
+	go func() {
+		select {
+		case <-ctx.Done():
+			close(stop)
+		case <-finished:
+		}
+	}()
+	limits.Stop = stop
+
+	info := position.Board.Search(position.Player, limits, s.eval, nil)
+	response := searchResponse{
+		Score: info.Score,
+		Depth: info.Depth,
+		Nodes: info.Nodes,
+		PV:    info.PV,
+	}
+	if bestMove, ok := info.BestMove(); ok {
+		response.BestMove = &bestMove
+	}
+	return response, nil
+
+}
+
+func (request positionRequest) position() (core.Position, error) {
+
+	switch {
+	case request.FEN != "" && request.Board != "":
+		return core.Position{}, fmt.Errorf("give either fen or board, not both")
+	case request.FEN != "":
+		position, err := core.ParseFEN(request.FEN)
+		if err != nil {
+			return core.Position{}, err
+		}
+		if err := position.Board.Validate(); err != nil {
+			return core.Position{}, err
+		}
+		return position, nil
+	case request.Board != "":
+		if request.Player == nil {
+			return core.Position{}, fmt.Errorf("player is required with board")
+		}
+		board, err := core.ParseBoard(request.Board)
+		if err != nil {
+			return core.Position{}, err
+		}
+		if err := board.Validate(); err != nil {
+			return core.Position{}, err
+		}
+		return core.Position{Board: board, Player: *request.Player}, nil
+	default:
+		return core.Position{}, fmt.Errorf("fen or board is required")
+	}
+
+}
+
+func newPositionResponse(position core.Position) positionResponse {
+	return positionResponse{
+		FEN:    position.FEN(),
+		Board:  position.Board.CompactString(false),
+		Player: position.Player,
+	}
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
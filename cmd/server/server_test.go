@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/couchbaselabs/go.assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	core "github.com/tleyden/checkers-core"
+)
+
+func post(t *testing.T, path string, body string) (int, map[string]interface{}) {
+	request := httptest.NewRequest("POST", path, strings.NewReader(body))
+	recorder := httptest.NewRecorder()
+	newServer().ServeHTTP(recorder, request)
+	response := map[string]interface{}{}
+	assert.True(t, json.Unmarshal(recorder.Body.Bytes(), &response) == nil)
+	return recorder.Code, response
+}
+
+func TestLegalMovesEndpoint(t *testing.T) {
+
+	status, response := post(t, "/legalmoves", `{"fen": "B:W21-32:B1-12"}`)
+	assert.Equals(t, status, http.StatusOK)
+	moves := response["moves"].([]interface{})
+	assert.Equals(t, len(moves), 7)
+	move := moves[0].(map[string]interface{})
+	assert.True(t, move["notation"] != "")
+
+	body := map[string]interface{}{
+		"board":  core.NewStartingBoard().CompactString(false),
+		"player": "red",
+	}
+	data, _ := json.Marshal(body)
+	status, response = post(t, "/legalmoves", string(data))
+	assert.Equals(t, status, http.StatusOK)
+	assert.Equals(t, len(response["moves"].([]interface{})), 7)
+
+}
+
+func TestApplyEndpoint(t *testing.T) {
+
+	status, response := post(t, "/apply", `{"fen": "B:W21-32:B1-12", "move": "11-15"}`)
+	assert.Equals(t, status, http.StatusOK)
+	assert.Equals(t, response["player"], "red")
+	assert.Equals(t, response["fen"], "W:W21,22,23,24,25,26,27,28,29,30,31,32:B1,2,3,4,5,6,7,8,9,10,12,15")
+
+	status, response = post(t, "/apply", `{"fen": "B:W21-32:B1-12", "move": "11-14"}`)
+	assert.Equals(t, status, http.StatusBadRequest)
+	assert.True(t, strings.Contains(response["error"].(string), "illegal move"))
+
+}
+
+func TestEvaluateEndpoint(t *testing.T) {
+
+	status, response := post(t, "/evaluate", `{"fen": "B:W21-32:B1-12"}`)
+	assert.Equals(t, status, http.StatusOK)
+	assert.Equals(t, response["score"], 0.0)
+
+	status, response = post(t, "/evaluate", `{"fen": "B:WK21:B1,2"}`)
+	assert.Equals(t, status, http.StatusOK)
+	assert.True(t, response["score"].(float64) > 0)
+
+}
+
+func TestSearchEndpoint(t *testing.T) {
+
+	status, response := post(t, "/search", `{"fen": "B:W21-32:B1-12", "depth": 3}`)
+	assert.Equals(t, status, http.StatusOK)
+	assert.Equals(t, response["depth"], 3.0)
+	assert.True(t, response["bestmove"] != nil)
+	assert.Equals(t, len(response["pv"].([]interface{})), 3)
+
+	status, response = post(t, "/search", `{"fen": "B:W21:B", "depth": 3}`)
+	assert.Equals(t, status, http.StatusOK)
+	assert.True(t, response["bestmove"] == nil)
+
+	status, _ = post(t, "/search", `{"fen": "B:W21-32:B1-12", "depth": -1}`)
+	assert.Equals(t, status, http.StatusBadRequest)
+
+}
+
+// The search stops as soon as the client goes away
+func TestSearchCancelled(t *testing.T) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	body := `{"fen": "B:W21-32:B1-12", "depth": 64, "movetime_ms": 10000}`
+	request := httptest.NewRequest("POST", "/search", strings.NewReader(body)).WithContext(ctx)
+	recorder := httptest.NewRecorder()
+
+	s := newServer()
+	s.maxDepth = 64
+	start := time.Now()
+	s.ServeHTTP(recorder, request)
+	assert.True(t, time.Since(start) < 5*time.Second)
+
+}
+
+func TestEndpointErrors(t *testing.T) {
+
+	bodies := []string{
+		`not json`,
+		`{}`,
+		`{"fen": "B:W21-32"}`,
+		`{"board": "|- o - o|", "player": "red"}`,
+		`{"board": "|- - - - - - - -|"}`,
+		`{"fen": "B:W21-32:B1-12", "board": "|- - - - - - - -|"}`,
+		`{"fen": "B:W1:B5"}`,
+		`{"fen": "B:W21-32:B1-13"}`,
+	}
+	for _, body := range bodies {
+		status, response := post(t, "/legalmoves", body)
+		assert.Equals(t, status, http.StatusBadRequest)
+		assert.True(t, response["error"] != "")
+	}
+
+	request := httptest.NewRequest("GET", "/legalmoves", nil)
+	recorder := httptest.NewRecorder()
+	newServer().ServeHTTP(recorder, request)
+	assert.Equals(t, recorder.Code, http.StatusMethodNotAllowed)
+
+}
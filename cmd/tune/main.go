@@ -0,0 +1,91 @@
+/*
+Tune the evaluation weights against labeled positions, Texel style.
+
+Each position is labeled with the result of its game, from the point of
+view of the player to move: 1 for a win, 0.5 for a draw and 0 for a
+loss.  The tuner minimizes the mean squared error between the labels and
+sigmoid(k * eval), where eval is the weighted evaluation of the position,
+by local search: each weight in turn is nudged up and down by the step
+size, keeping any change that reduces the error, and the step is halved
+whenever no change helps.  Unless given, k is fitted to the starting
+weights first.
+
+Positions are read from the files given as arguments, either positions
+written by cmd/selfplay (.jsonl, using the fen and outcome) or games in
+PDN (.pdn, labeling every position with the game's result):
+
+	tune -out tuned.weights selfplay/positions.jsonl games.pdn
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	core "github.com/tleyden/checkers-core"
+)
+
+func main() {
+
+	weightsPath := flag.String("weights", "", "starting weights file, instead of the defaults")
+	out := flag.String("out", "tuned.weights", "file to write the tuned weights to")
+	k := flag.Float64("k", 0, "sigmoid scaling constant, or 0 to fit it")
+	step := flag.Float64("step", 0.05, "initial step size")
+	iterations := flag.Int("iterations", 100, "maximum number of passes over the weights")
+	flag.Parse()
+
+	if err := tuneFiles(*weightsPath, *out, flag.Args(), *k, *step, *iterations); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+}
+
+func tuneFiles(weightsPath, out string, paths []string, k, step float64, iterations int) error {
+
+	if len(paths) == 0 {
+		return fmt.Errorf("no position files given")
+	}
+	weights := core.DefaultEvalWeights()
+	if weightsPath != "" {
+		file, err := os.Open(weightsPath)
+		if err != nil {
+			return err
+		}
+		weights, err = core.ReadEvalWeights(file)
+		file.Close()
+		if err != nil {
+			return fmt.Errorf("%v: %v", weightsPath, err)
+		}
+	}
+
+	samples := []sample{}
+	for _, path := range paths {
+		loaded, err := loadSamples(path)
+		if err != nil {
+			return fmt.Errorf("%v: %v", path, err)
+		}
+		samples = append(samples, loaded...)
+	}
+	if len(samples) == 0 {
+		return fmt.Errorf("no positions found")
+	}
+
+	tuner := &tuner{samples: samples, k: k, step: step, iterations: iterations, log: os.Stderr}
+	tuned, err := tuner.tune(weights)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(out)
+	if err != nil {
+		return err
+	}
+	if err := tuned.Write(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+
+	core "github.com/tleyden/checkers-core"
+)
+
+// A labeled position, reduced to its evaluation features
+type sample struct {
+	features []float64
+	result   float64 // from the point of view of the player to move
+}
+
+type tuner struct {
+	samples    []sample
+	k          float64
+	step       float64
+	iterations int
+	log        io.Writer
+}
+
+func newSample(board core.Board, player core.Player, result float64) sample {
+	return sample{features: board.EvalFeatures(player), result: result}
+}
+
+func loadSamples(path string) ([]sample, error) {
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	switch filepath.Ext(path) {
+	case ".jsonl":
+		return readPositionSamples(file)
+	case ".pdn":
+		return readPDNSamples(file)
+	}
+	return nil, fmt.Errorf("unknown file type, expected .jsonl or .pdn")
+
+}
+
+// Read positions written by cmd/selfplay, one JSON object per line
+func readPositionSamples(reader io.Reader) ([]sample, error) {
+
+	samples := []sample{}
+	scanner := bufio.NewScanner(reader)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber += 1
+		record := struct {
+			FEN     string   `json:"fen"`
+			Outcome *float64 `json:"outcome"`
+		}{}
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("line %d: %v", lineNumber, err)
+		}
+		if record.Outcome == nil {
+			return nil, fmt.Errorf("line %d: missing outcome", lineNumber)
+		}
+		position, err := core.ParseFEN(record.FEN)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", lineNumber, err)
+		}
+		samples = append(samples, newSample(position.Board, position.Player, *record.Outcome))
+	}
+	return samples, scanner.Err()
+
+}
+
+// Label every position in the games with the game's result.  Games
+// without a result are skipped.
+func readPDNSamples(reader io.Reader) ([]sample, error) {
+
+	games, err := core.ReadPDN(reader)
+	if err != nil {
+		return nil, err
+	}
+	samples := []sample{}
+	for i, game := range games {
+		if game.Result == core.UNKNOWN_RESULT {
+			continue
+		}
+		err := game.Replay(func(board core.Board, player core.Player, move core.Move) {
+			samples = append(samples, newSample(board, player, game.Result.ScoreFor(player)))
+		})
+		if err != nil {
+			return nil, fmt.Errorf("game %d: %v", i+1, err)
+		}
+	}
+	return samples, nil
+
+}
+
+// Tune the weights, starting from initial
+func (t *tuner) tune(initial core.EvalWeights) (core.EvalWeights, error) {
+
+	weights := initial.Vector()
+	if t.k <= 0 {
+		t.k = t.fitK(weights)
+		t.logf("fitted k = %.4f", t.k)
+	}
+
+	best := t.meanError(weights)
+	t.logf("initial error %.6f", best)
+	step := t.step
+	for iteration := 1; iteration <= t.iterations && step > 1e-4; iteration++ {
+		improved := false
+		for i := range weights {
+			for _, delta := range []float64{step, -step} {
+				weights[i] += delta
+				if err := t.meanError(weights); err < best {
+					best = err
+					improved = true
+					break
+				}
+				weights[i] -= delta
+			}
+		}
+		t.logf("iteration %d: error %.6f, step %v", iteration, best, step)
+		if !improved {
+			step /= 2
+		}
+	}
+	return core.NewEvalWeights(weights)
+
+}
+
+// The mean squared error between the results and the predictions
+func (t *tuner) meanError(weights []float64) float64 {
+	return meanError(t.samples, weights, t.k)
+}
+
+func meanError(samples []sample, weights []float64, k float64) float64 {
+	total := 0.0
+	for _, s := range samples {
+		eval := 0.0
+		for i, feature := range s.features {
+			eval += weights[i] * feature
+		}
+		prediction := 1 / (1 + math.Exp(-k*eval))
+		total += (s.result - prediction) * (s.result - prediction)
+	}
+	return total / float64(len(samples))
+}
+
+// Find the k minimizing the error of the weights, by golden section
+// search
+func (t *tuner) fitK(weights []float64) float64 {
+	low, high := 0.01, 10.0
+	ratio := (math.Sqrt(5) - 1) / 2
+	for high-low > 1e-4 {
+		a := high - ratio*(high-low)
+		b := low + ratio*(high-low)
+		if meanError(t.samples, weights, a) < meanError(t.samples, weights, b) {
+			high = b
+		} else {
+			low = a
+		}
+	}
+	return (low + high) / 2
+}
+
+func (t *tuner) logf(format string, args ...interface{}) {
+	if t.log != nil {
+		fmt.Fprintf(t.log, format+"\n", args...)
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/couchbaselabs/go.assert"
+	core "github.com/tleyden/checkers-core"
+)
+
+func TestTuneRecoversWeights(t *testing.T) {
+
+	// results depend only on the kings, with a weight of 2
+	samples := []sample{}
+	for kings := -2.0; kings <= 2; kings++ {
+		for men := -3.0; men <= 3; men++ {
+			result := 1 / (1 + math.Exp(-2*kings))
+			samples = append(samples, sample{features: []float64{men, kings, 0, 0, 0}, result: result})
+		}
+	}
+
+	tuner := &tuner{samples: samples, k: 1, step: 0.1, iterations: 500}
+	assert.True(t, tuner.meanError(core.DefaultEvalWeights().Vector()) > 0.01)
+	tuned, err := tuner.tune(core.DefaultEvalWeights())
+	assert.True(t, err == nil)
+	assert.True(t, math.Abs(tuned.Man) < 0.01)
+	assert.True(t, math.Abs(tuned.King-2) < 0.01)
+	assert.True(t, tuner.meanError(tuned.Vector()) < 1e-5)
+
+}
+
+func TestFitK(t *testing.T) {
+	samples := []sample{}
+	for men := -3.0; men <= 3; men++ {
+		samples = append(samples, sample{features: []float64{men, 0, 0, 0, 0}, result: 1 / (1 + math.Exp(-0.5*men))})
+	}
+	tuner := &tuner{samples: samples}
+	assert.True(t, math.Abs(tuner.fitK(core.DefaultEvalWeights().Vector())-0.5) < 0.001)
+}
+
+func TestTuneFiles(t *testing.T) {
+
+	dir := t.TempDir()
+	positions := filepath.Join(dir, "positions.jsonl")
+	games := filepath.Join(dir, "games.pdn")
+	out := filepath.Join(dir, "tuned.weights")
+	os.WriteFile(positions, []byte(`{"fen": "B:W18:B1,2", "score": 1, "outcome": 1}
+{"fen": "W:W18:B1,2", "score": -1, "outcome": 0}
+`), 0644)
+	os.WriteFile(games, []byte("[Result \"1-0\"]\n\n1. 11-15 23-19 2. 8-11 22-17 1-0\n"), 0644)
+
+	samples, err := loadSamples(positions)
+	assert.True(t, err == nil)
+	assert.Equals(t, len(samples), 2)
+	assert.Equals(t, samples[0].features[0], 1.0)
+	assert.Equals(t, samples[1].features[0], -1.0)
+
+	samples, err = loadSamples(games)
+	assert.True(t, err == nil)
+	assert.Equals(t, len(samples), 4)
+	assert.Equals(t, samples[1].result, 0.0)
+
+	err = tuneFiles("", out, []string{positions, games}, 0, 0.05, 10)
+	assert.True(t, err == nil)
+	data, err := os.ReadFile(out)
+	assert.True(t, err == nil)
+	weights, err := core.ReadEvalWeights(strings.NewReader(string(data)))
+	assert.True(t, err == nil)
+	assert.True(t, weights.Man > 0)
+
+	_, err = loadSamples(filepath.Join(dir, "positions.csv"))
+	assert.True(t, err != nil)
+
+}
@@ -0,0 +1,20 @@
+package checkerscore
+
+/*
+Czech draughts: kings fly and men only capture forwards.  Any capture may
+be chosen, except that a capture by a king must be played if there is
+one.  The pieces start on the same squares as in English checkers, but
+white (red) moves first.
+*/
+var CZECH_VARIANT = &Variant{
+	Name:        "czech",
+	FlyingKings: true,
+	CaptureFilter: func(board Board, captures []Move) []Move {
+		return board.kingCapturesFirst(captures)
+	},
+}
+
+func init() {
+	registerVariant(CZECH_VARIANT)
+	registerStartingPosition("czech", englishStartingBoard, RED_PLAYER)
+}
@@ -0,0 +1,40 @@
+package checkerscore
+
+import (
+	"github.com/couchbaselabs/go.assert"
+	"testing"
+)
+
+func TestCzechPerft(t *testing.T) {
+	position := CZECH_VARIANT.NewStartingPosition()
+	assert.Equals(t, position.Player, RED_PLAYER)
+	expected := []int{7, 49, 302, 1469, 7361, 36768}
+	for i, count := range expected {
+		assert.Equals(t, position.Perft(i+1), count)
+	}
+
+	// the start position only plays differently from English checkers
+	// once kings appear, so compare them in a position with kings
+	position, _ = ParseFEN("W:W21,22,23,24,25,26,K31:B5,6,7,8,9,K3")
+	position.Variant = CZECH_VARIANT
+	expected = []int{8, 56, 450, 2961, 20967}
+	for i, count := range expected {
+		assert.Equals(t, position.Perft(i+1), count)
+	}
+	position.Variant = ENGLISH_VARIANT
+	assert.Equals(t, position.Perft(5), 18220)
+}
+
+func TestCzechCaptures(t *testing.T) {
+
+	// a capture by a king must be played
+	position, _ := ParseFEN("W:W21,K30:B17,26")
+	position.Variant = CZECH_VARIANT
+	assert.Equals(t, sortedNotations(position.LegalMoves()), []string{"30x12", "30x16", "30x19", "30x23"})
+
+	// otherwise any capture may be chosen
+	position, _ = ParseFEN("W:W22:B17,18,10")
+	position.Variant = CZECH_VARIANT
+	assert.Equals(t, sortedNotations(position.LegalMoves()), []string{"22x13", "22x15x6"})
+
+}
@@ -0,0 +1,399 @@
+/*
+Package dxp implements the DXP (Draughts eXchange Protocol) used for
+engine versus engine matches over TCP, adapted to the 32 squares of a
+checkers board.
+
+Every message is a line of ASCII text starting with a one character
+type, using fixed width fields, and terminated by a null byte:
+
+	R  GAMEREQ  version(2) name(32) follower color(1) minutes(3) moves(3)
+	            start(1) [color to move(1) position(32)]
+	A  GAMEACC  name(32) accept code(1)
+	M  MOVE     seconds(4) from(2) to(2) captures(2) captured(2 each)
+	E  GAMEEND  reason(1) stop code(1)
+	C  CHAT     text
+	B  BACKREQ  move number(3) color to move(1)
+	K  BACKACC  accept code(1)
+
+Colors are "W" for white, which refers to the red pieces starting on
+squares 21-32, and "Z" for black.  Positions use "e" for empty squares,
+"w" and "z" for men and "W" and "Z" for kings.
+*/
+package dxp
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	core "github.com/tleyden/checkers-core"
+)
+
+const (
+	Version = "01"
+
+	nameWidth  = 32
+	terminator = byte(0)
+)
+
+// Acceptance codes for GameAccept
+const (
+	ACCEPT          = 0
+	REFUSE_COLOR    = 1
+	REFUSE_TIME     = 2
+	REFUSE_POSITION = 3
+	REFUSE          = 9
+)
+
+// Acceptance codes for BackAccept
+const (
+	BACK_ACCEPT        = 0
+	BACK_NOT_SUPPORTED = 1
+	BACK_REFUSE        = 2
+)
+
+// Reasons for GameEnd
+const (
+	END_UNKNOWN = 0
+	END_I_LOSE  = 1
+	END_DRAW    = 2
+	END_I_WIN   = 3
+)
+
+type Message interface {
+	Encode() string
+}
+
+type GameRequest struct {
+	Name          string
+	FollowerColor core.Player // the color played by the receiver
+	Minutes       int         // thinking time for the game
+	Moves         int         // number of moves to be played in that time
+	Position      *core.Position
+}
+
+type GameAccept struct {
+	Name       string
+	AcceptCode int
+}
+
+type MoveMessage struct {
+	Seconds  int
+	From     int
+	To       int
+	Captured []int
+}
+
+type GameEnd struct {
+	Reason   int
+	StopCode int // 0 if another game is welcome, 1 otherwise
+}
+
+type Chat struct {
+	Text string
+}
+
+type BackRequest struct {
+	MoveNumber int
+	Player     core.Player
+}
+
+type BackAccept struct {
+	AcceptCode int
+}
+
+// A connection to another DXP engine
+type Conn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+func NewConn(conn net.Conn) *Conn {
+	return &Conn{conn: conn, reader: bufio.NewReader(conn)}
+}
+
+func (c *Conn) Send(message Message) error {
+	_, err := c.conn.Write(append([]byte(message.Encode()), terminator))
+	return err
+}
+
+// Wait for the next message
+func (c *Conn) Receive() (Message, error) {
+	data, err := c.reader.ReadString(terminator)
+	if err != nil {
+		return nil, err
+	}
+	return ParseMessage(strings.TrimSuffix(data, "\x00"))
+}
+
+func (c *Conn) Close() error {
+	return c.conn.Close()
+}
+
+func (m GameRequest) Encode() string {
+	start := "A"
+	position := ""
+	if m.Position != nil {
+		start = "B"
+		position = encodeColor(m.Position.Player) + encodePosition(m.Position.Board)
+	}
+	return fmt.Sprintf(
+		"R%s%s%s%03d%03d%s%s",
+		Version,
+		padName(m.Name),
+		encodeColor(m.FollowerColor),
+		m.Minutes,
+		m.Moves,
+		start,
+		position,
+	)
+}
+
+func (m GameAccept) Encode() string {
+	return fmt.Sprintf("A%s%d", padName(m.Name), m.AcceptCode)
+}
+
+func (m MoveMessage) Encode() string {
+	captured := ""
+	for _, square := range m.Captured {
+		captured += fmt.Sprintf("%02d", square)
+	}
+	return fmt.Sprintf("M%04d%02d%02d%02d%s", m.Seconds, m.From, m.To, len(m.Captured), captured)
+}
+
+func (m GameEnd) Encode() string {
+	return fmt.Sprintf("E%d%d", m.Reason, m.StopCode)
+}
+
+func (m Chat) Encode() string {
+	return "C" + m.Text
+}
+
+func (m BackRequest) Encode() string {
+	return fmt.Sprintf("B%03d%s", m.MoveNumber, encodeColor(m.Player))
+}
+
+func (m BackAccept) Encode() string {
+	return fmt.Sprintf("K%d", m.AcceptCode)
+}
+
+func ParseMessage(data string) (Message, error) {
+
+	if len(data) == 0 {
+		return nil, fmt.Errorf("empty message")
+	}
+	fields := &fieldReader{data: data, pos: 1}
+
+	var message Message
+	switch data[0] {
+	case 'R':
+		request := GameRequest{}
+		if version := fields.str(2); version != Version {
+			return nil, fmt.Errorf("unsupported version: %q", version)
+		}
+		request.Name = strings.TrimSpace(fields.str(nameWidth))
+		request.FollowerColor = fields.color()
+		request.Minutes = fields.number(3)
+		request.Moves = fields.number(3)
+		switch fields.str(1) {
+		case "A":
+		case "B":
+			position := core.Position{Player: fields.color()}
+			position.Board = fields.position()
+			request.Position = &position
+		default:
+			fields.fail("invalid start position indicator")
+		}
+		message = request
+	case 'A':
+		message = GameAccept{
+			Name:       strings.TrimSpace(fields.str(nameWidth)),
+			AcceptCode: fields.number(1),
+		}
+	case 'M':
+		move := MoveMessage{
+			Seconds: fields.number(4),
+			From:    fields.number(2),
+			To:      fields.number(2),
+		}
+		numCaptured := fields.number(2)
+		for i := 0; i < numCaptured && fields.err == nil; i++ {
+			move.Captured = append(move.Captured, fields.number(2))
+		}
+		message = move
+	case 'E':
+		message = GameEnd{Reason: fields.number(1), StopCode: fields.number(1)}
+	case 'C':
+		message = Chat{Text: data[1:]}
+		fields.pos = len(data)
+	case 'B':
+		message = BackRequest{MoveNumber: fields.number(3), Player: fields.color()}
+	case 'K':
+		message = BackAccept{AcceptCode: fields.number(1)}
+	default:
+		return nil, fmt.Errorf("unknown message type: %q", data[0])
+	}
+
+	if fields.err == nil && fields.pos != len(data) {
+		fields.fail("unexpected trailing data")
+	}
+	if fields.err != nil {
+		return nil, fields.err
+	}
+	return message, nil
+
+}
+
+// Create a move message for a legal move
+func NewMoveMessage(move core.Move, seconds int) MoveMessage {
+	message := MoveMessage{
+		Seconds: seconds,
+		From:    move.From().SquareNumber(),
+		To:      move.To().SquareNumber(),
+	}
+	for _, loc := range move.Captured() {
+		message.Captured = append(message.Captured, loc.SquareNumber())
+	}
+	return message
+}
+
+// Find the legal move in position described by this message
+func (m MoveMessage) ToMove(position core.Position) (core.Move, error) {
+	for _, move := range position.LegalMoves() {
+		if NewMoveMessage(move, m.Seconds).sameMove(m) {
+			return move, nil
+		}
+	}
+	return core.Move{}, fmt.Errorf("illegal move: %v", m.Encode())
+}
+
+// Whether two messages describe the same move, ignoring the order of
+// the captured squares
+func (m MoveMessage) sameMove(other MoveMessage) bool {
+	if m.From != other.From || m.To != other.To || len(m.Captured) != len(other.Captured) {
+		return false
+	}
+	remaining := map[int]int{}
+	for _, square := range m.Captured {
+		remaining[square] += 1
+	}
+	for _, square := range other.Captured {
+		if remaining[square] == 0 {
+			return false
+		}
+		remaining[square] -= 1
+	}
+	return true
+}
+
+// Reads fixed width fields, remembering the first error
+type fieldReader struct {
+	data string
+	pos  int
+	err  error
+}
+
+func (f *fieldReader) fail(reason string) {
+	if f.err == nil {
+		f.err = fmt.Errorf("invalid message %q: %v", f.data, reason)
+	}
+}
+
+func (f *fieldReader) str(width int) string {
+	if f.err != nil {
+		return ""
+	}
+	if f.pos+width > len(f.data) {
+		f.fail("message too short")
+		return ""
+	}
+	value := f.data[f.pos : f.pos+width]
+	f.pos += width
+	return value
+}
+
+func (f *fieldReader) number(width int) int {
+	str := f.str(width)
+	if f.err != nil {
+		return 0
+	}
+	value, err := strconv.Atoi(strings.TrimSpace(str))
+	if err != nil || value < 0 {
+		f.fail(fmt.Sprintf("invalid number %q", str))
+	}
+	return value
+}
+
+func (f *fieldReader) color() core.Player {
+	switch str := f.str(1); str {
+	case "W":
+		return core.RED_PLAYER
+	case "Z":
+		return core.BLACK_PLAYER
+	default:
+		f.fail(fmt.Sprintf("invalid color %q", str))
+		return core.RED_PLAYER
+	}
+}
+
+func (f *fieldReader) position() core.Board {
+	board := core.NewEmptyBoard()
+	str := f.str(32)
+	for i, square := range str {
+		var piece core.Piece
+		switch square {
+		case 'e':
+			continue
+		case 'w':
+			piece = core.RED
+		case 'W':
+			piece = core.RED_KING
+		case 'z':
+			piece = core.BLACK
+		case 'Z':
+			piece = core.BLACK_KING
+		default:
+			f.fail(fmt.Sprintf("invalid square %q", square))
+			return board
+		}
+		loc, _ := core.NewLocationFromSquareNumber(i + 1)
+		board[loc.Row()][loc.Col()] = piece
+	}
+	return board
+}
+
+func encodeColor(player core.Player) string {
+	if player == core.RED_PLAYER {
+		return "W"
+	}
+	return "Z"
+}
+
+func encodePosition(board core.Board) string {
+	squares := []byte{}
+	for square := 1; square <= 32; square++ {
+		loc, _ := core.NewLocationFromSquareNumber(square)
+		switch board.PieceAt(loc) {
+		case core.RED:
+			squares = append(squares, 'w')
+		case core.RED_KING:
+			squares = append(squares, 'W')
+		case core.BLACK:
+			squares = append(squares, 'z')
+		case core.BLACK_KING:
+			squares = append(squares, 'Z')
+		default:
+			squares = append(squares, 'e')
+		}
+	}
+	return string(squares)
+}
+
+func padName(name string) string {
+	if len(name) > nameWidth {
+		name = name[:nameWidth]
+	}
+	return fmt.Sprintf("%-32s", name)
+}
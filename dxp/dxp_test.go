@@ -0,0 +1,110 @@
+package dxp
+
+import (
+	"github.com/couchbaselabs/go.assert"
+	"net"
+	"testing"
+
+	core "github.com/tleyden/checkers-core"
+)
+
+func TestEncodeMessages(t *testing.T) {
+
+	request := GameRequest{Name: "checkers-core", FollowerColor: core.RED_PLAYER, Minutes: 5, Moves: 40}
+	assert.Equals(t, request.Encode(), "R01checkers-core                   W005040A")
+
+	accept := GameAccept{Name: "opponent", AcceptCode: ACCEPT}
+	assert.Equals(t, accept.Encode(), "Aopponent                        0")
+
+	move := MoveMessage{Seconds: 12, From: 30, To: 16, Captured: []int{26, 19}}
+	assert.Equals(t, move.Encode(), "M00123016022619")
+
+	assert.Equals(t, GameEnd{Reason: END_I_LOSE, StopCode: 1}.Encode(), "E11")
+	assert.Equals(t, Chat{Text: "good game"}.Encode(), "Cgood game")
+	assert.Equals(t, BackRequest{MoveNumber: 7, Player: core.BLACK_PLAYER}.Encode(), "B007Z")
+	assert.Equals(t, BackAccept{AcceptCode: BACK_ACCEPT}.Encode(), "K0")
+
+}
+
+func TestParseMessages(t *testing.T) {
+
+	position := core.NewStartingPosition()
+	messages := []Message{
+		GameRequest{Name: "checkers-core", FollowerColor: core.BLACK_PLAYER, Minutes: 5, Moves: 40},
+		GameRequest{Name: "checkers-core", FollowerColor: core.RED_PLAYER, Minutes: 1, Moves: 1, Position: &position},
+		GameAccept{Name: "opponent", AcceptCode: REFUSE_TIME},
+		MoveMessage{Seconds: 3, From: 11, To: 15},
+		MoveMessage{Seconds: 12, From: 30, To: 16, Captured: []int{26, 19}},
+		GameEnd{Reason: END_DRAW},
+		Chat{Text: "hello"},
+		BackRequest{MoveNumber: 12, Player: core.RED_PLAYER},
+		BackAccept{AcceptCode: BACK_REFUSE},
+	}
+	for _, message := range messages {
+		parsed, err := ParseMessage(message.Encode())
+		assert.True(t, err == nil)
+		assert.Equals(t, parsed, message)
+	}
+
+	invalid := []string{
+		"",
+		"X",
+		"R02checkers-core                   W005040A",
+		"R01checkers-core                   Q005040A",
+		"R01checkers-core                   W005040B",
+		"M001230",
+		"M0012301602261",
+		"M00123016xx",
+		"E1",
+		"E123",
+		"B007X",
+	}
+	for _, data := range invalid {
+		_, err := ParseMessage(data)
+		assert.True(t, err != nil)
+	}
+
+}
+
+func TestMoveMessageToMove(t *testing.T) {
+
+	position, _ := core.ParseFEN("W:W30:B26,19")
+	move := position.LegalMoves()[0]
+	message := NewMoveMessage(move, 1)
+	assert.Equals(t, message.From, 30)
+	assert.Equals(t, message.To, 16)
+	assert.Equals(t, message.Captured, []int{26, 19})
+
+	// captured squares may be in any order
+	message.Captured = []int{19, 26}
+	parsed, err := message.ToMove(position)
+	assert.True(t, err == nil)
+	assert.Equals(t, parsed.Notation(), "30x23x16")
+
+	message.Captured = []int{19}
+	_, err = message.ToMove(position)
+	assert.True(t, err != nil)
+
+}
+
+func TestConn(t *testing.T) {
+
+	client, server := net.Pipe()
+	clientConn := NewConn(client)
+	serverConn := NewConn(server)
+	defer clientConn.Close()
+	defer serverConn.Close()
+
+	go func() {
+		clientConn.Send(Chat{Text: "hello"})
+		clientConn.Send(GameEnd{Reason: END_I_WIN, StopCode: 1})
+	}()
+
+	message, err := serverConn.Receive()
+	assert.True(t, err == nil)
+	assert.Equals(t, message, Chat{Text: "hello"})
+	message, err = serverConn.Receive()
+	assert.True(t, err == nil)
+	assert.Equals(t, message, GameEnd{Reason: END_I_WIN, StopCode: 1})
+
+}
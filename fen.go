@@ -0,0 +1,160 @@
+package checkerscore
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+/*
+FEN strings as used in PDN, eg:
+
+	B:W18,24,27,28,K10,K15:B12,16,20,K22,K25,K29
+
+The first field is the player to move, followed by a list of squares for
+each player, where a K prefix marks a king.  W (white) refers to the red
+pieces, which start on squares 21-32, and B to the black pieces, which
+start on squares 1-12.  Ranges of squares such as "1-12" are accepted
+when parsing.
+
+Squares are numbered as in English checkers, except for variants played
+on a mirrored board, which use their own numbering.
+*/
+
+func ParseFEN(fen string) (Position, error) {
+	return ParseFENForVariant(fen, nil)
+}
+
+// Parse a FEN string describing a position in the given variant, using
+// the variant's square numbers
+func ParseFENForVariant(fen string, variant *Variant) (Position, error) {
+
+	position := Position{Board: NewEmptyBoard(), Variant: variant}
+
+	fen = strings.TrimSuffix(strings.TrimSpace(fen), ".")
+	fields := strings.Split(fen, ":")
+	if len(fields) != 3 {
+		return position, fmt.Errorf("invalid FEN %q: expected 3 fields", fen)
+	}
+
+	player, err := parseFENPlayer(fields[0])
+	if err != nil {
+		return position, fmt.Errorf("invalid FEN %q: %v", fen, err)
+	}
+	position.Player = player
+
+	for _, field := range fields[1:] {
+		if err := position.Board.addFENPieces(field, variant); err != nil {
+			return position, fmt.Errorf("invalid FEN %q: %v", fen, err)
+		}
+	}
+	return position, nil
+
+}
+
+// Convert to a FEN string.  Pieces on light squares are not included.
+func (position Position) FEN() string {
+
+	buffer := bytes.Buffer{}
+	buffer.WriteString(fenPlayer(position.Player))
+	for _, player := range []Player{RED_PLAYER, BLACK_PLAYER} {
+		buffer.WriteString(":")
+		buffer.WriteString(fenPlayer(player))
+		squares := []string{}
+		for square := 1; square <= 32; square++ {
+			loc, _ := position.Variant.LocationOfSquare(square)
+			piece := position.Board.pieceAt(loc)
+			if !piece.OwnedBy(player) {
+				continue
+			}
+			prefix := ""
+			if piece.IsKing() {
+				prefix = "K"
+			}
+			squares = append(squares, prefix+strconv.Itoa(square))
+		}
+		buffer.WriteString(strings.Join(squares, ","))
+	}
+	return buffer.String()
+
+}
+
+func fenPlayer(player Player) string {
+	switch player {
+	case RED_PLAYER:
+		return "W"
+	default:
+		return "B"
+	}
+}
+
+func parseFENPlayer(str string) (Player, error) {
+	switch strings.TrimSpace(str) {
+	case "W":
+		return RED_PLAYER, nil
+	case "B":
+		return BLACK_PLAYER, nil
+	}
+	return RED_PLAYER, fmt.Errorf("invalid player %q", str)
+}
+
+// Add the pieces from a FEN field such as "W18,24,K10" to the board,
+// numbering the squares as in variant
+func (board *Board) addFENPieces(field string, variant *Variant) error {
+
+	field = strings.TrimSpace(field)
+	if field == "" {
+		return fmt.Errorf("empty field")
+	}
+	player, err := parseFENPlayer(field[:1])
+	if err != nil {
+		return err
+	}
+
+	for _, token := range strings.Split(field[1:], ",") {
+		token = strings.TrimSpace(token)
+		if token == "" {
+			continue
+		}
+		piece := getPlayerPiece(player)
+		if strings.HasPrefix(token, "K") {
+			piece = piece.King()
+			token = token[1:]
+		}
+
+		first, last, err := parseFENSquares(token)
+		if err != nil {
+			return err
+		}
+		for square := first; square <= last; square++ {
+			loc, err := variant.LocationOfSquare(square)
+			if err != nil {
+				return err
+			}
+			if board.pieceAt(loc) != EMPTY {
+				return fmt.Errorf("square %d given twice", square)
+			}
+			board[loc.row][loc.col] = piece
+		}
+	}
+	return nil
+
+}
+
+// Parse a single square "18" or a range of squares "1-12"
+func parseFENSquares(token string) (first, last int, err error) {
+	bounds := strings.SplitN(token, "-", 2)
+	first, err = strconv.Atoi(bounds[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid square %q", token)
+	}
+	last = first
+	if len(bounds) == 2 {
+		last, err = strconv.Atoi(bounds[1])
+		if err != nil || last < first {
+			return 0, 0, fmt.Errorf("invalid square range %q", token)
+		}
+	}
+	return first, last, nil
+}
@@ -0,0 +1,45 @@
+package checkerscore
+
+import (
+	"github.com/couchbaselabs/go.assert"
+	"testing"
+)
+
+func TestParseFEN(t *testing.T) {
+
+	position, err := ParseFEN("B:W21-32:B1-12")
+	assert.True(t, err == nil)
+	assert.True(t, position == NewStartingPosition())
+	assert.Equals(t, position.FEN(), "B:W21,22,23,24,25,26,27,28,29,30,31,32:B1,2,3,4,5,6,7,8,9,10,11,12")
+
+	fen := "W:W18,24,27,28,K10,K15:B12,16,20,K22,K25,K29"
+	position, err = ParseFEN(fen)
+	assert.True(t, err == nil)
+	assert.Equals(t, position.Player, RED_PLAYER)
+	assert.Equals(t, position.Board.PieceAt(NewLocation(2, 3)), RED_KING)
+	assert.Equals(t, position.Board.PieceAt(NewLocation(5, 6)), RED)
+	assert.Equals(t, position.Board.PieceAt(NewLocation(2, 7)), BLACK)
+	assert.Equals(t, position.Board.PieceAt(NewLocation(7, 0)), BLACK_KING)
+	assert.Equals(t, position.FEN(), "W:WK10,K15,18,24,27,28:B12,16,20,K22,K25,K29")
+
+	roundTrip, err := ParseFEN(position.FEN())
+	assert.True(t, err == nil)
+	assert.True(t, roundTrip.Board.PieceAt(NewLocation(7, 0)) == BLACK_KING)
+
+}
+
+func TestParseFENErrors(t *testing.T) {
+	invalid := []string{
+		"",
+		"B:W21-32",
+		"X:W21-32:B1-12",
+		"B:W21-32:W1-12x",
+		"B:W21-33:B1-12",
+		"B:W1-12:B12",
+		"B:W12-1:B",
+	}
+	for _, fen := range invalid {
+		_, err := ParseFEN(fen)
+		assert.True(t, err != nil)
+	}
+}
@@ -0,0 +1,92 @@
+package checkerscore
+
+import (
+	"fmt"
+)
+
+// A game in progress, recording the moves played so they can be undone
+type Game struct {
+	Start    Position
+	Position Position
+	Moves    []Move
+
+	history []Position // the positions before each move in Moves
+}
+
+// A new game from the standard starting position
+func NewGame() *Game {
+	return NewGameFromPosition(NewStartingPosition())
+}
+
+func NewGameFromPosition(start Position) *Game {
+	return &Game{
+		Start:    start,
+		Position: start,
+		Moves:    []Move{},
+		history:  []Position{},
+	}
+}
+
+func (game *Game) LegalMoves() []Move {
+	return game.Position.LegalMoves()
+}
+
+// Play a move for the player to move, returning an error if it's not
+// one of their legal moves.
+func (game *Game) Play(move Move) error {
+	if game.IsOver() {
+		return fmt.Errorf("game is over: %v", game.Result())
+	}
+	if !containsMove(game.LegalMoves(), move) {
+		return fmt.Errorf("illegal move: %v", move.Notation())
+	}
+	game.history = append(game.history, game.Position)
+	game.Moves = append(game.Moves, move)
+	game.Position = game.Position.ApplyMove(move)
+	return nil
+}
+
+// Play a move given in standard notation, eg "11-15"
+func (game *Game) PlayNotation(notation string) (Move, error) {
+	move, err := game.Position.ParseMove(notation)
+	if err != nil {
+		return Move{}, err
+	}
+	return move, game.Play(move)
+}
+
+// Take back the last move, returning false if there are no moves.
+func (game *Game) Undo() bool {
+	if len(game.Moves) == 0 {
+		return false
+	}
+	last := len(game.Moves) - 1
+	game.Position = game.history[last]
+	game.history = game.history[:last]
+	game.Moves = game.Moves[:last]
+	return true
+}
+
+// The game is over when the player to move has no legal moves, which
+// includes having no pieces left, and they lose (or win, in giveaway).
+func (game *Game) Result() GameResult {
+	if len(game.LegalMoves()) > 0 {
+		return UNKNOWN_RESULT
+	}
+	return game.Position.Variant.winnerWithoutMoves(game.Position.Player)
+}
+
+func (game *Game) IsOver() bool {
+	return game.Result() != UNKNOWN_RESULT
+}
+
+// Unlike Move.ContainedIn, this distinguishes multiple jumps which start
+// and end on the same squares but take different paths.
+func containsMove(moves []Move, move Move) bool {
+	for _, curMove := range moves {
+		if curMove.compactString() == move.compactString() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package checkerscore
+
+import (
+	"github.com/couchbaselabs/go.assert"
+	"testing"
+)
+
+func TestGamePlayAndUndo(t *testing.T) {
+
+	game := NewGame()
+	assert.False(t, game.IsOver())
+	assert.Equals(t, game.Result(), UNKNOWN_RESULT)
+
+	_, err := game.PlayNotation("11-15")
+	assert.True(t, err == nil)
+	_, err = game.PlayNotation("23-19")
+	assert.True(t, err == nil)
+	assert.Equals(t, len(game.Moves), 2)
+	assert.Equals(t, game.Position.Player, BLACK_PLAYER)
+
+	// it's black's turn, so red's moves are illegal
+	_, err = game.PlayNotation("22-18")
+	assert.True(t, err != nil)
+	assert.Equals(t, len(game.Moves), 2)
+
+	assert.True(t, game.Undo())
+	assert.True(t, game.Undo())
+	assert.False(t, game.Undo())
+	assert.True(t, game.Position == NewStartingPosition())
+
+}
+
+func TestGameRejectsMoveWithWrongPath(t *testing.T) {
+
+	position, _ := ParseFEN("W:WK21:B17,18,25,26")
+	game := NewGameFromPosition(position)
+	moves := game.LegalMoves()
+	assert.Equals(t, len(moves), 2)
+
+	// a move with the same start and end squares, but a path that
+	// isn't legal: jumping the same piece there and back
+	first := moves[0].Submoves()[0]
+	back := Move{from: first.to, over: first.over, to: first.from}
+	bogus := NewMove([]Move{first, back})
+	assert.True(t, bogus.ContainedIn(moves))
+	assert.True(t, game.Play(bogus) != nil)
+	assert.True(t, game.Play(moves[1]) == nil)
+
+}
+
+func TestGameResult(t *testing.T) {
+
+	position, _ := ParseFEN("W:W14:B10")
+	game := NewGameFromPosition(position)
+	_, err := game.PlayNotation("14x7")
+	assert.True(t, err == nil)
+	assert.True(t, game.IsOver())
+	assert.Equals(t, game.Result(), RED_WINS)
+
+	_, err = game.PlayNotation("1-5")
+	assert.True(t, err != nil)
+
+	// a player who can't move loses even with pieces left
+	position, _ = ParseFEN("B:W:B29")
+	position.Board[7][0] = BLACK
+	game = NewGameFromPosition(position)
+	assert.Equals(t, len(game.LegalMoves()), 0)
+	assert.Equals(t, game.Result(), RED_WINS)
+
+}
@@ -0,0 +1,159 @@
+package checkerscore
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"io"
+	"time"
+)
+
+// Options for WriteGIF.  Zero values are replaced by the defaults.
+type GIFOptions struct {
+	Size       int           // width and height of each frame in pixels, rounded down to a multiple of 8
+	Delay      time.Duration // how long each frame is shown
+	FinalDelay time.Duration // how long the final position is shown
+	Flipped    bool          // view from the black side, with black at the bottom
+}
+
+var defaultGIFOptions = GIFOptions{
+	Size:       400,
+	Delay:      time.Second,
+	FinalDelay: 3 * time.Second,
+}
+
+// Indexes into gifPalette
+const (
+	gifLight = iota
+	gifDark
+	gifRed
+	gifBlack
+	gifOutline
+	gifCrown
+)
+
+var gifPalette = color.Palette{
+	gifLight:   color.RGBA{0xf0, 0xd9, 0xb5, 0xff},
+	gifDark:    color.RGBA{0xb5, 0x88, 0x63, 0xff},
+	gifRed:     color.RGBA{0xc0, 0x39, 0x2b, 0xff},
+	gifBlack:   color.RGBA{0x22, 0x22, 0x22, 0xff},
+	gifOutline: color.RGBA{0xff, 0xff, 0xff, 0xff},
+	gifCrown:   color.RGBA{0xf1, 0xc4, 0x0f, 0xff},
+}
+
+/*
+Write an animated GIF of the moves played from the start position, one
+frame per position, under the start position's variant.  Multiple jumps
+get a frame for each jump.  The player making each move is the owner of
+the piece it moves, and an error is returned if there's no piece to move.
+*/
+func WriteGIF(w io.Writer, start Position, moves []Move, options GIFOptions) error {
+
+	options = options.withDefaults()
+	animation := &gif.GIF{}
+	addFrame := func(board Board, delay time.Duration) {
+		animation.Image = append(animation.Image, board.gifFrame(options, start.Variant))
+		animation.Delay = append(animation.Delay, int(delay/(10*time.Millisecond)))
+	}
+
+	board := start.Board
+	for i, move := range moves {
+		piece := board.pieceAt(move.from)
+		if piece == EMPTY {
+			return fmt.Errorf("move %d (%v): no piece to move", i+1, start.MoveNotation(move))
+		}
+		player := piece.Owner()
+		addFrame(board, options.Delay)
+		// the last jump is shown by the next frame
+		for jumps := 1; jumps < len(move.submoves); jumps++ {
+			addFrame(board.afterJumps(player, move, jumps, start.Variant), options.Delay)
+		}
+		board = board.ApplyMoveForVariant(player, move, start.Variant)
+	}
+	addFrame(board, options.FinalDelay)
+
+	return gif.EncodeAll(w, animation)
+
+}
+
+// Write an animated GIF of the moves played so far
+func (game *Game) WriteGIF(w io.Writer, options GIFOptions) error {
+	return WriteGIF(w, game.Start, game.Moves, options)
+}
+
+// The board partway through a multiple jump, once the first jumps have
+// been made.  The jumping piece is only promoted on the way if the
+// variant promotes mid capture.
+func (board Board) afterJumps(player Player, move Move, jumps int, variant *Variant) Board {
+	partial := NewMove(move.submoves[:jumps])
+	after := board.ApplyMoveForVariant(player, partial, variant)
+	if !variant.rules().PromoteMidCapture {
+		after[partial.to.row][partial.to.col] = board.pieceAt(move.from)
+	}
+	return after
+}
+
+func (options GIFOptions) withDefaults() GIFOptions {
+	if options.Size <= 0 {
+		options.Size = defaultGIFOptions.Size
+	}
+	// so the squares fill the frame
+	options.Size -= options.Size % 8
+	if options.Size == 0 {
+		options.Size = 8
+	}
+	if options.Delay <= 0 {
+		options.Delay = defaultGIFOptions.Delay
+	}
+	if options.FinalDelay <= 0 {
+		options.FinalDelay = defaultGIFOptions.FinalDelay
+	}
+	return options
+}
+
+// A single frame, shading the playable squares of variant's board
+func (board Board) gifFrame(options GIFOptions, variant *Variant) *image.Paletted {
+
+	frame := image.NewPaletted(image.Rect(0, 0, options.Size, options.Size), gifPalette)
+	square := options.Size / 8
+
+	board.applyEachSquare(func(loc Location) {
+		row, col := loc.row, loc.col
+		if options.Flipped {
+			row, col = 7-row, 7-col
+		}
+		left, top := col*square, row*square
+		center := float64(square) / 2
+
+		squareColor := uint8(gifLight)
+		if variant.isPlayable(loc) {
+			squareColor = gifDark
+		}
+		piece := board.pieceAt(loc)
+		pieceColor := uint8(gifRed)
+		if piece.OwnedBy(BLACK_PLAYER) {
+			pieceColor = gifBlack
+		}
+
+		for y := 0; y < square; y++ {
+			for x := 0; x < square; x++ {
+				index := squareColor
+				dx, dy := float64(x)+0.5-center, float64(y)+0.5-center
+				distance := (dx*dx + dy*dy) / (center * center)
+				switch {
+				case piece == EMPTY:
+				case piece.IsKing() && distance < 0.1:
+					index = gifCrown
+				case distance < 0.55:
+					index = pieceColor
+				case distance < 0.65:
+					index = gifOutline
+				}
+				frame.SetColorIndex(left+x, top+y, index)
+			}
+		}
+	})
+	return frame
+
+}
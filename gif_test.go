@@ -0,0 +1,99 @@
+package checkerscore
+
+import (
+	"bytes"
+	"github.com/couchbaselabs/go.assert"
+	"image/gif"
+	"testing"
+	"time"
+)
+
+func TestWriteGIF(t *testing.T) {
+
+	position, _ := ParseFEN("B:W9,18,32:BK5")
+	jump, _ := position.Board.ParseMove(BLACK_PLAYER, "5x14x23")
+	game := NewGameFromPosition(position)
+	assert.True(t, game.Play(jump) == nil)
+	_, err := game.PlayNotation("32-27")
+	assert.True(t, err == nil)
+
+	buffer := bytes.Buffer{}
+	err = game.WriteGIF(&buffer, GIFOptions{Size: 80, Delay: 500 * time.Millisecond})
+	assert.True(t, err == nil)
+
+	animation, err := gif.DecodeAll(&buffer)
+	assert.True(t, err == nil)
+
+	// start, after the first jump, after the second jump, after red's move
+	assert.Equals(t, len(animation.Image), 4)
+	assert.Equals(t, animation.Delay, []int{50, 50, 50, 300})
+	assert.Equals(t, animation.Image[0].Bounds().Dx(), 80)
+
+	// the king is drawn in the middle of its square after each jump
+	crown := func(frame int, loc Location) bool {
+		image := animation.Image[frame]
+		return image.ColorIndexAt(loc.col*10+5, loc.row*10+5) == gifCrown
+	}
+	assert.True(t, crown(0, Location{row: 1, col: 0}))
+	assert.True(t, crown(1, Location{row: 3, col: 2}))
+	assert.False(t, crown(1, Location{row: 1, col: 0}))
+	assert.True(t, crown(2, Location{row: 5, col: 4}))
+	assert.Equals(t, animation.Image[1].ColorIndexAt(15, 25), uint8(gifDark))
+	assert.Equals(t, animation.Image[1].ColorIndexAt(35, 45), uint8(gifRed))
+	assert.Equals(t, animation.Image[2].ColorIndexAt(35, 45), uint8(gifDark))
+
+}
+
+func TestWriteGIFVariant(t *testing.T) {
+
+	// a russian king capturing from a distance, with the piece it
+	// captures gone in the final frame
+	position, _ := ParseFEN("W:WK29:B18")
+	position.Variant = RUSSIAN_VARIANT
+	game := NewGameFromPosition(position)
+	_, err := game.PlayNotation("29x15")
+	assert.True(t, err == nil)
+
+	buffer := bytes.Buffer{}
+	assert.True(t, game.WriteGIF(&buffer, GIFOptions{Size: 80}) == nil)
+	animation, err := gif.DecodeAll(&buffer)
+	assert.True(t, err == nil)
+	assert.Equals(t, len(animation.Image), 2)
+	assert.Equals(t, animation.Image[0].ColorIndexAt(35, 45), uint8(gifBlack))
+	assert.Equals(t, animation.Image[1].ColorIndexAt(35, 45), uint8(gifDark))
+	assert.Equals(t, animation.Image[1].ColorIndexAt(45, 35), uint8(gifCrown))
+
+	// a brazilian man passing over the last row mid capture isn't shown
+	// promoted, and isn't promoted at the end
+	position, _ = ParseFEN("W:W11:B7,6")
+	position.Variant = BRAZILIAN_VARIANT
+	game = NewGameFromPosition(position)
+	_, err = game.PlayNotation("11x2x9")
+	assert.True(t, err == nil)
+
+	buffer.Reset()
+	assert.True(t, game.WriteGIF(&buffer, GIFOptions{Size: 80}) == nil)
+	animation, _ = gif.DecodeAll(&buffer)
+	assert.Equals(t, len(animation.Image), 3)
+	assert.Equals(t, animation.Image[1].ColorIndexAt(35, 5), uint8(gifRed))
+	assert.Equals(t, animation.Image[2].ColorIndexAt(15, 25), uint8(gifRed))
+
+}
+
+func TestWriteGIFSize(t *testing.T) {
+
+	for size, expected := range map[int]int{85: 80, 87: 80, 88: 88, 5: 8} {
+		var buffer bytes.Buffer
+		assert.True(t, WriteGIF(&buffer, NewStartingPosition(), nil, GIFOptions{Size: size}) == nil)
+		animation, err := gif.DecodeAll(&buffer)
+		assert.True(t, err == nil)
+		assert.Equals(t, animation.Image[0].Bounds().Dx(), expected)
+	}
+
+}
+
+func TestWriteGIFErrors(t *testing.T) {
+	move, _ := NewStartingBoard().ParseMove(BLACK_PLAYER, "11-15")
+	err := WriteGIF(&bytes.Buffer{}, Position{Board: NewEmptyBoard()}, []Move{move}, GIFOptions{})
+	assert.Equals(t, err.Error(), "move 1 (11-15): no piece to move")
+}
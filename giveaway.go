@@ -0,0 +1,28 @@
+package checkerscore
+
+/*
+Giveaway, or losing checkers: pieces move and capture as in English
+checkers, captures included, but the aim is to be left without a move,
+usually by losing all your pieces.
+*/
+var GIVEAWAY_VARIANT = &Variant{
+	Name:     "giveaway",
+	Giveaway: true,
+}
+
+func init() {
+	registerVariant(GIVEAWAY_VARIANT)
+	registerStartingPosition("giveaway", englishStartingBoard, BLACK_PLAYER)
+}
+
+// Evaluates positions for giveaway: the fewer pieces the better, and
+// having no moves left is a win.
+func GiveawayEvaluationFunction() EvaluationFunction {
+	evalFunc := func(player Player, board Board) float64 {
+		if len(board.LegalMoves(player)) == 0 {
+			return WinScore
+		}
+		return -board.WeightedScore(player)
+	}
+	return evalFunc
+}
@@ -0,0 +1,48 @@
+package checkerscore
+
+import (
+	"github.com/couchbaselabs/go.assert"
+	"testing"
+)
+
+func TestGiveawayResult(t *testing.T) {
+
+	position, _ := ParseFEN("W:W32:B28,K23")
+	position.Variant = GIVEAWAY_VARIANT
+	game := NewGameFromPosition(position)
+	_, err := game.PlayNotation("32-27")
+	assert.True(t, err == nil)
+	_, err = game.PlayNotation("23x32")
+	assert.True(t, err == nil)
+
+	// red has lost all their pieces, and so has won
+	assert.True(t, game.IsOver())
+	assert.Equals(t, game.Result(), RED_WINS)
+
+	game.Position.Variant = nil
+	assert.Equals(t, game.Result(), BLACK_WINS)
+
+}
+
+func TestGiveawaySearch(t *testing.T) {
+
+	// moving to 15 gives the only black piece away
+	position, _ := ParseFEN("B:W19:B10")
+
+	move, _ := position.Board.Minimax(BLACK_PLAYER, 2, GiveawayEvaluationFunction())
+	assert.Equals(t, move.Notation(), "10-15")
+	move, _ = position.Board.Minimax(BLACK_PLAYER, 2, DefaultEvaluationFunction())
+	assert.Equals(t, move.Notation(), "10-14")
+
+	position.Variant = GIVEAWAY_VARIANT
+	info := position.Search(SearchLimits{Depth: 4}, GiveawayEvaluationFunction(), nil)
+	move, _ = info.BestMove()
+	assert.Equals(t, move.Notation(), "10-15")
+	assert.Equals(t, info.Score, WinScore-2)
+
+	position.Variant = nil
+	info = position.Search(SearchLimits{Depth: 4}, DefaultEvaluationFunction(), nil)
+	move, _ = info.BestMove()
+	assert.Equals(t, move.Notation(), "10-14")
+
+}
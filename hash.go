@@ -0,0 +1,42 @@
+package checkerscore
+
+import (
+	"math/rand"
+)
+
+// Random keys used for Zobrist hashing, one for each piece on each
+// square plus one for the side to move.  They are generated from a fixed
+// seed so that hashes are stable across runs and can be stored in files.
+var zobristPieceKeys [8][8][5]uint64
+var zobristBlackToMoveKey uint64
+
+func init() {
+	rng := rand.New(rand.NewSource(0x636865636b657273))
+	for row := 0; row < 8; row++ {
+		for col := 0; col < 8; col++ {
+			for piece := range zobristPieceKeys[row][col] {
+				zobristPieceKeys[row][col][piece] = rng.Uint64()
+			}
+		}
+	}
+	zobristBlackToMoveKey = rng.Uint64()
+}
+
+// Compute a Zobrist hash of the board with player to move.
+func (board Board) Hash(player Player) uint64 {
+
+	hash := uint64(0)
+	for row := 0; row < 8; row++ {
+		for col := 0; col < 8; col++ {
+			piece := board[row][col]
+			if piece != EMPTY {
+				hash ^= zobristPieceKeys[row][col][piece]
+			}
+		}
+	}
+	if player == BLACK_PLAYER {
+		hash ^= zobristBlackToMoveKey
+	}
+	return hash
+
+}
@@ -0,0 +1,231 @@
+package checkerscore
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+)
+
+/*
+International draughts, played on a 10x10 board with 20 pieces per side.
+The rules differ from checkers in that:
+
+  - men capture backwards as well as forwards
+  - kings fly: they move any distance along a diagonal, and capture a
+    piece any distance away, landing on any empty square beyond it
+  - the capture taking the most pieces must be played
+  - captured pieces are removed at the end of the move, and can't be
+    jumped twice
+  - men only promote if the move ends on the last row
+
+As on a checkers Board, black starts at the top of the board and red at
+the bottom.  Red plays the role of white and moves first.  The 50
+playable squares are numbered from 1 in the top left:
+
+		"|-  1 -  2 -  3 -  4 -  5|"
+		"| 6 -  7 -  8 -  9 - 10 -|"
+		...
+		"|46 - 47 - 48 - 49 - 50 -|"
+*/
+
+const INTERNATIONAL_SIZE = 10
+
+// The player who moves first in international draughts
+const INTERNATIONAL_FIRST_PLAYER = RED_PLAYER
+
+type InternationalBoard [INTERNATIONAL_SIZE][INTERNATIONAL_SIZE]Piece
+
+func NewInternationalStartingBoard() InternationalBoard {
+	board := InternationalBoard{}
+	for row := 0; row < INTERNATIONAL_SIZE; row++ {
+		for col := 0; col < INTERNATIONAL_SIZE; col++ {
+			if (row+col)%2 == 0 {
+				continue
+			}
+			switch {
+			case row < 4:
+				board[row][col] = BLACK
+			case row >= 6:
+				board[row][col] = RED
+			}
+		}
+	}
+	return board
+}
+
+// Parse a compact board string with 10 rows of 10 squares, in the same
+// format as for ParseBoard
+func ParseInternationalBoard(compactBoard string) (InternationalBoard, error) {
+
+	board := InternationalBoard{}
+	squares := 0
+	_, tokensChannel := lex("boardlexer", compactBoard)
+
+	for token := range tokensChannel {
+		var piece Piece
+		switch token.typ {
+		case itemError:
+			return InternationalBoard{}, fmt.Errorf("invalid board: %v", token.val)
+		case itemEOF:
+			continue
+		case itemSquareRed:
+			piece = RED
+		case itemSquareRedKing:
+			piece = RED_KING
+		case itemSquareBlack:
+			piece = BLACK
+		case itemSquareBlackKing:
+			piece = BLACK_KING
+		}
+		if squares < INTERNATIONAL_SIZE*INTERNATIONAL_SIZE {
+			board[squares/INTERNATIONAL_SIZE][squares%INTERNATIONAL_SIZE] = piece
+		}
+		squares += 1
+	}
+
+	if squares != INTERNATIONAL_SIZE*INTERNATIONAL_SIZE {
+		return InternationalBoard{}, fmt.Errorf("invalid board: expected 100 squares, got %d", squares)
+	}
+	return board, nil
+
+}
+
+func (board InternationalBoard) CompactString(addNewlines bool) string {
+
+	buffer := bytes.Buffer{}
+	if addNewlines {
+		buffer.WriteString("\n")
+	}
+	for row := 0; row < INTERNATIONAL_SIZE; row++ {
+		buffer.WriteString("|")
+		for col := 0; col < INTERNATIONAL_SIZE; col++ {
+			buffer.WriteString(board[row][col].String())
+			if col < INTERNATIONAL_SIZE-1 {
+				buffer.WriteString(" ")
+			}
+		}
+		buffer.WriteString("|")
+		if addNewlines {
+			buffer.WriteString("\n")
+		}
+	}
+	return buffer.String()
+
+}
+
+func (board InternationalBoard) PieceAt(loc Location) Piece {
+	if loc.isOffBoardOfSize(INTERNATIONAL_SIZE) {
+		return EMPTY
+	}
+	return board[loc.row][loc.col]
+}
+
+// The rules of international draughts, for the shared move generator
+var internationalRules = &Variant{
+	Name:                "international",
+	MenCaptureBackwards: true,
+	FlyingKings:         true,
+	MaximumCapture:      true,
+}
+
+// The legal moves for player.  Only the captures taking the most pieces
+// are returned when any captures are possible.
+func (board InternationalBoard) LegalMoves(player Player) []Move {
+	return internationalRules.legalMoves(board, player)
+}
+
+// Apply a legal move, removing the captured pieces and promoting a man
+// which ends its move on the last row.
+func (board InternationalBoard) ApplyMove(player Player, move Move) InternationalBoard {
+
+	piece := internationalRules.movedPiece(board, player, move)
+	board[move.from.row][move.from.col] = EMPTY
+	for _, loc := range move.Captured() {
+		board[loc.row][loc.col] = EMPTY
+	}
+	board[move.to.row][move.to.col] = piece
+	return board
+
+}
+
+// Count the leaf nodes of the move tree to the given depth
+func (board InternationalBoard) Perft(player Player, depth int) int {
+	if depth == 0 {
+		return 1
+	}
+	moves := board.LegalMoves(player)
+	if depth == 1 {
+		return len(moves)
+	}
+	total := 0
+	for _, move := range moves {
+		total += board.ApplyMove(player, move).Perft(player.Opponent(), depth-1)
+	}
+	return total
+}
+
+// Serialize a move in international notation, eg "32-28" or "28x19x10"
+func (board InternationalBoard) MoveNotation(move Move) string {
+	separator := "-"
+	if move.IsJump() {
+		separator = "x"
+	}
+	buffer := bytes.Buffer{}
+	for i, loc := range move.path() {
+		if i > 0 {
+			buffer.WriteString(separator)
+		}
+		buffer.WriteString(strconv.Itoa(loc.InternationalSquareNumber()))
+	}
+	return buffer.String()
+}
+
+// Find the legal move for player described by the given notation.  As
+// for Board.ParseMove, captures may list every landing square or just
+// the from and to squares.
+func (board InternationalBoard) ParseMove(player Player, notation string) (Move, error) {
+
+	squares, err := parseNotationSquaresWith(notation, NewLocationFromInternationalSquareNumber)
+	if err != nil {
+		return Move{}, err
+	}
+
+	return matchNotation(board.LegalMoves(player), squares, notation)
+
+}
+
+// Returns the international square number (1-50) of this location, or
+// 0 if the location is not a playable square.
+func (loc Location) InternationalSquareNumber() int {
+	if loc.isOffBoardOfSize(INTERNATIONAL_SIZE) || !loc.isDarkSquare() {
+		return 0
+	}
+	return loc.row*5 + loc.col/2 + 1
+}
+
+// Returns the location of the given international square number (1-50)
+func NewLocationFromInternationalSquareNumber(square int) (Location, error) {
+	if square < 1 || square > 50 {
+		return Location{}, fmt.Errorf("invalid square number: %d", square)
+	}
+	index := square - 1
+	row := index / 5
+	col := (index % 5) * 2
+	if row%2 == 0 {
+		col += 1
+	}
+	return Location{row: row, col: col}, nil
+}
+
+func (board InternationalBoard) size() int {
+	return INTERNATIONAL_SIZE
+}
+
+func (board InternationalBoard) pieceAt(loc Location) Piece {
+	return board[loc.row][loc.col]
+}
+
+func (board InternationalBoard) without(loc Location) squares {
+	board[loc.row][loc.col] = EMPTY
+	return board
+}
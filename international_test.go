@@ -0,0 +1,183 @@
+package checkerscore
+
+import (
+	"github.com/couchbaselabs/go.assert"
+	"testing"
+)
+
+func TestInternationalPerft(t *testing.T) {
+	board := NewInternationalStartingBoard()
+	expected := []int{9, 81, 658, 4265, 27117}
+	if testing.Short() {
+		expected = expected[:4]
+	}
+	for i, count := range expected {
+		assert.Equals(t, board.Perft(INTERNATIONAL_FIRST_PLAYER, i+1), count)
+	}
+}
+
+func TestInternationalCompactString(t *testing.T) {
+
+	board := NewInternationalStartingBoard()
+	compact := board.CompactString(true)
+	parsed, err := ParseInternationalBoard(compact)
+	assert.True(t, err == nil)
+	assert.Equals(t, parsed, board)
+	assert.Equals(t, board.CompactString(false)[:21], "|- o - o - o - o - o|")
+
+	_, err = ParseInternationalBoard(NewStartingBoard().CompactString(false))
+	assert.Equals(t, err.Error(), "invalid board: expected 100 squares, got 64")
+
+}
+
+func TestInternationalSquareNumbers(t *testing.T) {
+	for square := 1; square <= 50; square++ {
+		loc, err := NewLocationFromInternationalSquareNumber(square)
+		assert.True(t, err == nil)
+		assert.Equals(t, loc.InternationalSquareNumber(), square)
+	}
+	loc, _ := NewLocationFromInternationalSquareNumber(46)
+	assert.Equals(t, loc, Location{row: 9, col: 0})
+	_, err := NewLocationFromInternationalSquareNumber(51)
+	assert.True(t, err != nil)
+}
+
+func TestInternationalMenCaptureBackwards(t *testing.T) {
+
+	board, _ := ParseInternationalBoard("" +
+		"|- - - - - - - - - -|" +
+		"|- - - - - - - - - -|" +
+		"|- - - - - - - - - -|" +
+		"|- - - - - - - - - -|" +
+		"|- - - - - - - - - -|" +
+		"|- - - - x - - - - -|" +
+		"|- - - o - - - - - -|" +
+		"|- - - - - - - - - -|" +
+		"|- - - - - - - - - -|" +
+		"|- - - - - - - - - -|")
+
+	moves := board.LegalMoves(RED_PLAYER)
+	assert.Equals(t, len(moves), 1)
+	assert.Equals(t, board.MoveNotation(moves[0]), "28x37")
+
+}
+
+func TestInternationalMajorityCapture(t *testing.T) {
+
+	// the man on 32 can take one piece, the man on 35 two
+	board, _ := ParseInternationalBoard("" +
+		"|- - - - - - - - - -|" +
+		"|- - - - - - - - - -|" +
+		"|- - - - - - - - - -|" +
+		"|- - - - - - o - - -|" +
+		"|- - - - - - - - - -|" +
+		"|- - o - - - - - o -|" +
+		"|- x - - - - - - - x|" +
+		"|- - - - - - - - - -|" +
+		"|- - - - - - - - - -|" +
+		"|- - - - - - - - - -|")
+
+	moves := board.LegalMoves(RED_PLAYER)
+	assert.Equals(t, len(moves), 1)
+	assert.Equals(t, board.MoveNotation(moves[0]), "35x24x13")
+
+	after := board.ApplyMove(RED_PLAYER, moves[0])
+	assert.Equals(t, after.PieceAt(moves[0].To()), RED)
+	assert.Equals(t, len(after.LegalMoves(BLACK_PLAYER)), 1)
+
+}
+
+func TestInternationalFlyingKing(t *testing.T) {
+
+	board, _ := ParseInternationalBoard("" +
+		"|- - - - - - - - - -|" +
+		"|- - - - - - - - - -|" +
+		"|- - - - - - - - - -|" +
+		"|- - - - - - - - - -|" +
+		"|- - - - - - - - - -|" +
+		"|- - - - - - - - - -|" +
+		"|- - - - - - - - - -|" +
+		"|- - - - - - - - - -|" +
+		"|- - - - - - - - - -|" +
+		"|X - - - - - - - - -|")
+
+	// the king can move to any square on the long diagonal
+	moves := board.LegalMoves(RED_PLAYER)
+	assert.Equals(t, len(moves), 9)
+	move, err := board.ParseMove(RED_PLAYER, "46-37")
+	assert.True(t, err == nil)
+	assert.False(t, move.IsJump())
+	assert.Equals(t, board.MoveNotation(move), "46-37")
+
+	// a piece far away can be captured, landing on any square beyond
+	board[3][6] = BLACK
+	moves = board.LegalMoves(RED_PLAYER)
+	assert.Equals(t, len(moves), 3)
+	for _, move := range moves {
+		assert.Equals(t, move.Captured(), []Location{{row: 3, col: 6}})
+	}
+	move, err = board.ParseMove(RED_PLAYER, "46x5")
+	assert.True(t, err == nil)
+	after := board.ApplyMove(RED_PLAYER, move)
+	assert.Equals(t, after.PieceAt(Location{row: 0, col: 9}), RED_KING)
+	assert.Equals(t, after.PieceAt(Location{row: 3, col: 6}), EMPTY)
+
+}
+
+func TestInternationalKingMultipleCapture(t *testing.T) {
+
+	// the king must take both pieces, landing anywhere beyond the second
+	board, _ := ParseInternationalBoard("" +
+		"|- - - - - - - - - -|" +
+		"|- - - - - - - - - -|" +
+		"|- X - - - - - - - -|" +
+		"|- - o - - - - - - -|" +
+		"|- - - - - - - - - -|" +
+		"|- - - - - - - - - -|" +
+		"|- - - o - - - - - -|" +
+		"|- - - - - - - - - -|" +
+		"|- - - - - - - - - -|" +
+		"|- - - - - - - - - -|")
+
+	notations := []string{}
+	for _, move := range board.LegalMoves(RED_PLAYER) {
+		notations = append(notations, board.MoveNotation(move))
+	}
+	assert.Equals(t, notations, []string{"11x28x37", "11x28x41", "11x28x46"})
+
+	move, err := board.ParseMove(RED_PLAYER, "11x46")
+	assert.True(t, err == nil)
+	after := board.ApplyMove(RED_PLAYER, move)
+	assert.Equals(t, after.CompactString(false), ""+
+		"|- - - - - - - - - -|"+
+		"|- - - - - - - - - -|"+
+		"|- - - - - - - - - -|"+
+		"|- - - - - - - - - -|"+
+		"|- - - - - - - - - -|"+
+		"|- - - - - - - - - -|"+
+		"|- - - - - - - - - -|"+
+		"|- - - - - - - - - -|"+
+		"|- - - - - - - - - -|"+
+		"|X - - - - - - - - -|")
+
+}
+
+func TestInternationalPromotion(t *testing.T) {
+
+	board, _ := ParseInternationalBoard("" +
+		"|- - - - - - - - - -|" +
+		"|- - - - o - - - - -|" +
+		"|- - - x - - - - - -|" +
+		"|- - - - - - - - - -|" +
+		"|- - - - - - - - - -|" +
+		"|- - - - - - - - - -|" +
+		"|- - - - - - - - - -|" +
+		"|- - - - - - - - - -|" +
+		"|- - - - - - - - - -|" +
+		"|- - - - - - - - - -|")
+
+	move, err := board.ParseMove(RED_PLAYER, "12x3")
+	assert.True(t, err == nil)
+	assert.Equals(t, board.ApplyMove(RED_PLAYER, move).PieceAt(move.To()), RED_KING)
+
+}
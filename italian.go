@@ -0,0 +1,84 @@
+package checkerscore
+
+/*
+Italian draughts: kings move one square at a time, men can't capture
+kings, and captures must follow these priorities in turn:
+
+ 1. take the most pieces
+ 2. capture with a king rather than a man
+ 3. take the most kings
+ 4. take a king as early in the sequence as possible
+
+White (red) moves first.  The Italian board is the mirror image of the
+English one, so the pieces stand on the squares that are light in English
+checkers, but the squares are numbered from black's side as in English:
+black starts on squares 1-12 and white on squares 21-32.
+*/
+var ITALIAN_VARIANT = &Variant{
+	Name:                  "italian",
+	MaximumCapture:        true,
+	MenCannotCaptureKings: true,
+	CaptureFilter:         italianCapturePriority,
+	Mirrored:              true,
+}
+
+func init() {
+	registerVariant(ITALIAN_VARIANT)
+	registerStartingPosition("italian", mirroredStartingBoard, RED_PLAYER)
+}
+
+// Apply priorities 2-4 to captures which all take the most pieces
+func italianCapturePriority(board Board, captures []Move) []Move {
+
+	captures = board.kingCapturesFirst(captures)
+	captures = board.mostKingsCaptured(captures)
+	if len(board.capturedKings(captures[0])) == 0 {
+		return captures
+	}
+
+	earliestKing := -1
+	for _, capture := range captures {
+		if first := board.capturedKings(capture)[0]; earliestKing < 0 || first < earliestKing {
+			earliestKing = first
+		}
+	}
+	return filterMoves(captures, func(move Move) bool {
+		return board.capturedKings(move)[0] == earliestKing
+	})
+
+}
+
+// The captures made by kings, if there are any, otherwise all of them
+func (board Board) kingCapturesFirst(captures []Move) []Move {
+	byKing := filterMoves(captures, func(move Move) bool {
+		return board.pieceAt(move.from).IsKing()
+	})
+	if len(byKing) > 0 {
+		return byKing
+	}
+	return captures
+}
+
+// The captures taking the most kings
+func (board Board) mostKingsCaptured(captures []Move) []Move {
+	mostKings := 0
+	for _, capture := range captures {
+		if kings := len(board.capturedKings(capture)); kings > mostKings {
+			mostKings = kings
+		}
+	}
+	return filterMoves(captures, func(move Move) bool {
+		return len(board.capturedKings(move)) == mostKings
+	})
+}
+
+// The positions in the capture sequence at which kings are taken
+func (board Board) capturedKings(capture Move) []int {
+	kings := []int{}
+	for i, loc := range capture.Captured() {
+		if board.pieceAt(loc).IsKing() {
+			kings = append(kings, i)
+		}
+	}
+	return kings
+}
@@ -0,0 +1,46 @@
+package checkerscore
+
+import (
+	"github.com/couchbaselabs/go.assert"
+	"testing"
+)
+
+func italianMoves(t *testing.T, fen string) []string {
+	position, err := ParseFENForVariant(fen, ITALIAN_VARIANT)
+	assert.True(t, err == nil)
+	return sortedPositionNotations(position)
+}
+
+func TestItalianStart(t *testing.T) {
+	position := ITALIAN_VARIANT.NewStartingPosition()
+	assert.Equals(t, position.Player, RED_PLAYER)
+	assert.Equals(t, position.Perft(1), 7)
+	assert.Equals(t, position.FEN(), "W:W21,22,23,24,25,26,27,28,29,30,31,32:B1,2,3,4,5,6,7,8,9,10,11,12")
+}
+
+func TestItalianMenCannotCaptureKings(t *testing.T) {
+	assert.Equals(t, italianMoves(t, "W:W23:BK19"), []string{"23-20"})
+	assert.Equals(t, italianMoves(t, "W:W23:B19"), []string{"23x14"})
+}
+
+func TestItalianCapturePriority(t *testing.T) {
+
+	// 1. the most pieces
+	assert.Equals(t, italianMoves(t, "W:W23:B20,19,11"), []string{"23x14x7"})
+
+	// 2. with a king rather than a man
+	assert.Equals(t, italianMoves(t, "W:W24,K31:B20,27"), []string{"31x22"})
+
+	// 3. the most kings
+	assert.Equals(t, italianMoves(t, "W:WK31:BK27,28"), []string{"31x22"})
+
+	// 4. a king as early as possible
+	assert.Equals(t, italianMoves(t, "W:WK31:B28,K20,K27,18"), []string{"31x22x13"})
+
+	// without the priorities, every capture may be played
+	unfiltered := *ITALIAN_VARIANT
+	unfiltered.CaptureFilter = nil
+	position, _ := ParseFENForVariant("W:WK31:B28,K20,K27,18", &unfiltered)
+	assert.Equals(t, sortedPositionNotations(position), []string{"31x22x13", "31x24x15"})
+
+}
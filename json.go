@@ -0,0 +1,250 @@
+package checkerscore
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+/*
+JSON encoding of the core types.  The schema is:
+
+Piece: a one character string, as used in compact board strings
+
+	"-" (empty), "x" (red), "X" (red king), "o" (black), "O" (black king)
+
+Player: "red" or "black"
+
+Location: the row and column, counting from the top left
+
+	{"row": 5, "col": 0}
+
+Board: an array of eight rows, top to bottom, in compact string form
+
+	["|- o - o - o - o|", "|o - o - o - o -|", ... ]
+
+Move: the from and to locations, the captured locations (omitted if
+empty), and for jumps the individual jumps as submoves, each capturing a
+single piece.  A move is a jump exactly when it captures something, so a
+flying king moving two squares has no captured locations, while each leg
+of its capture from a distance lists the piece it takes.  The notation is
+informational and ignored when decoding.
+
+	{
+	  "from": {"row": 5, "col": 0},
+	  "to": {"row": 1, "col": 0},
+	  "captured": [{"row": 4, "col": 1}, {"row": 2, "col": 1}],
+	  "submoves": [
+	    {"from": {"row": 5, "col": 0}, "to": {"row": 3, "col": 2}, "captured": [{"row": 4, "col": 1}]},
+	    {"from": {"row": 3, "col": 2}, "to": {"row": 1, "col": 0}, "captured": [{"row": 2, "col": 1}]}
+	  ],
+	  "notation": "21x14x5"
+	}
+
+*/
+
+type jsonLocation struct {
+	Row int `json:"row"`
+	Col int `json:"col"`
+}
+
+type jsonMove struct {
+	From     Location   `json:"from"`
+	To       Location   `json:"to"`
+	Captured []Location `json:"captured,omitempty"`
+	Submoves []jsonMove `json:"submoves,omitempty"`
+	Notation string     `json:"notation,omitempty"`
+}
+
+func (piece Piece) MarshalJSON() ([]byte, error) {
+	return json.Marshal(piece.String())
+}
+
+func (piece *Piece) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	parsed, err := parsePiece(str)
+	if err != nil {
+		return err
+	}
+	*piece = parsed
+	return nil
+}
+
+func (player Player) MarshalJSON() ([]byte, error) {
+	return json.Marshal(player.String())
+}
+
+func (player *Player) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	switch str {
+	case "red":
+		*player = RED_PLAYER
+	case "black":
+		*player = BLACK_PLAYER
+	default:
+		return fmt.Errorf("invalid player: %q", str)
+	}
+	return nil
+}
+
+func (loc Location) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonLocation{Row: loc.row, Col: loc.col})
+}
+
+func (loc *Location) UnmarshalJSON(data []byte) error {
+	var decoded jsonLocation
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	parsed := Location{row: decoded.Row, col: decoded.Col}
+	if parsed.isOffBoard() {
+		return fmt.Errorf("location off board: (%d,%d)", decoded.Row, decoded.Col)
+	}
+	*loc = parsed
+	return nil
+}
+
+func (board Board) MarshalJSON() ([]byte, error) {
+	rows := []string{}
+	for row := 0; row < 8; row++ {
+		rows = append(rows, board.compactRowString(row))
+	}
+	return json.Marshal(rows)
+}
+
+func (board *Board) UnmarshalJSON(data []byte) error {
+
+	var rows []string
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return err
+	}
+	if len(rows) != 8 {
+		return fmt.Errorf("board must have 8 rows, got %d", len(rows))
+	}
+
+	parsed := Board{}
+	for row, rowStr := range rows {
+		squares := strings.Fields(strings.Trim(rowStr, pipe))
+		if len(squares) != 8 {
+			return fmt.Errorf("row %d must have 8 squares, got %d", row, len(squares))
+		}
+		for col, square := range squares {
+			piece, err := parsePiece(square)
+			if err != nil {
+				return fmt.Errorf("row %d: %v", row, err)
+			}
+			parsed[row][col] = piece
+		}
+	}
+	*board = parsed
+	return nil
+
+}
+
+func (move Move) MarshalJSON() ([]byte, error) {
+	return json.Marshal(move.toJSON())
+}
+
+func (move *Move) UnmarshalJSON(data []byte) error {
+	var decoded jsonMove
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	parsed, err := decoded.toMove()
+	if err != nil {
+		return err
+	}
+	*move = parsed
+	return nil
+}
+
+func (move Move) toJSON() jsonMove {
+	encoded := jsonMove{
+		From:     move.from,
+		To:       move.to,
+		Captured: move.Captured(),
+		Notation: move.Notation(),
+	}
+	for _, submove := range move.submoves {
+		encodedSubmove := submove.toJSON()
+		encodedSubmove.Notation = ""
+		encoded.Submoves = append(encoded.Submoves, encodedSubmove)
+	}
+	return encoded
+}
+
+func (encoded jsonMove) toMove() (Move, error) {
+
+	if len(encoded.Submoves) > 0 {
+		submoves := []Move{}
+		for _, encodedSubmove := range encoded.Submoves {
+			if len(encodedSubmove.Submoves) > 0 {
+				return Move{}, fmt.Errorf("submoves cannot have submoves")
+			}
+			if len(encodedSubmove.Captured) != 1 {
+				return Move{}, fmt.Errorf("each submove must capture one piece")
+			}
+			submove, err := encodedSubmove.toMove()
+			if err != nil {
+				return Move{}, err
+			}
+			if len(submoves) > 0 && !submove.from.Equals(submoves[len(submoves)-1].to) {
+				return Move{}, fmt.Errorf("each submove must start where the previous one ended")
+			}
+			submoves = append(submoves, submove)
+		}
+		move := NewMove(submoves)
+		if !move.from.Equals(encoded.From) || !move.to.Equals(encoded.To) {
+			return Move{}, fmt.Errorf("move from/to does not match its submoves")
+		}
+		if encoded.Captured != nil && !sameLocations(encoded.Captured, move.Captured()) {
+			return Move{}, fmt.Errorf("move captured does not match its submoves")
+		}
+		return move, nil
+	}
+
+	move := Move{from: encoded.From, to: encoded.To}
+	switch len(encoded.Captured) {
+	case 0:
+	case 1:
+		move.over = encoded.Captured[0]
+	default:
+		return Move{}, fmt.Errorf("multiple captures require submoves")
+	}
+	return move, nil
+
+}
+
+func sameLocations(a, b []Location) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, loc := range a {
+		if !loc.Equals(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func parsePiece(str string) (Piece, error) {
+	switch str {
+	case "-":
+		return EMPTY, nil
+	case "x":
+		return RED, nil
+	case "X":
+		return RED_KING, nil
+	case "o":
+		return BLACK, nil
+	case "O":
+		return BLACK_KING, nil
+	}
+	return EMPTY, fmt.Errorf("invalid piece: %q", str)
+}
@@ -0,0 +1,185 @@
+package checkerscore
+
+import (
+	"encoding/json"
+	"github.com/couchbaselabs/go.assert"
+	"strings"
+	"testing"
+)
+
+func TestPieceJSON(t *testing.T) {
+
+	for _, piece := range []Piece{EMPTY, RED, RED_KING, BLACK, BLACK_KING} {
+		data, err := json.Marshal(piece)
+		assert.True(t, err == nil)
+		var decoded Piece
+		assert.True(t, json.Unmarshal(data, &decoded) == nil)
+		assert.Equals(t, decoded, piece)
+	}
+
+	data, _ := json.Marshal(BLACK_KING)
+	assert.Equals(t, string(data), `"O"`)
+
+	var piece Piece
+	assert.True(t, json.Unmarshal([]byte(`"K"`), &piece) != nil)
+
+}
+
+func TestPlayerJSON(t *testing.T) {
+
+	data, _ := json.Marshal(BLACK_PLAYER)
+	assert.Equals(t, string(data), `"black"`)
+
+	var player Player
+	assert.True(t, json.Unmarshal([]byte(`"red"`), &player) == nil)
+	assert.Equals(t, player, RED_PLAYER)
+	assert.True(t, json.Unmarshal([]byte(`"white"`), &player) != nil)
+
+}
+
+func TestLocationJSON(t *testing.T) {
+
+	data, _ := json.Marshal(NewLocation(5, 0))
+	assert.Equals(t, string(data), `{"row":5,"col":0}`)
+
+	var loc Location
+	assert.True(t, json.Unmarshal(data, &loc) == nil)
+	assert.Equals(t, loc, NewLocation(5, 0))
+	assert.True(t, json.Unmarshal([]byte(`{"row":8,"col":0}`), &loc) != nil)
+
+}
+
+func TestBoardJSON(t *testing.T) {
+
+	board := NewStartingBoard()
+	data, err := json.Marshal(board)
+	assert.True(t, err == nil)
+
+	var rows []string
+	assert.True(t, json.Unmarshal(data, &rows) == nil)
+	assert.Equals(t, rows[0], "|- o - o - o - o|")
+	assert.Equals(t, rows[7], "|x - x - x - x -|")
+
+	var decoded Board
+	assert.True(t, json.Unmarshal(data, &decoded) == nil)
+	assert.True(t, decoded == board)
+
+	assert.True(t, json.Unmarshal([]byte(`["|- o - o|"]`), &decoded) != nil)
+
+	rows[3] = "|- - - - - - - -|" + "|- - - - - - - -|"
+	data, _ = json.Marshal(rows)
+	assert.True(t, json.Unmarshal(data, &decoded) != nil)
+
+}
+
+func TestMoveJSON(t *testing.T) {
+
+	board := NewStartingBoard()
+	move, _ := board.ParseMove(BLACK_PLAYER, "11-15")
+	data, err := json.Marshal(move)
+	assert.True(t, err == nil)
+	assert.Equals(t, string(data), `{"from":{"row":2,"col":5},"to":{"row":3,"col":4},"notation":"11-15"}`)
+
+	var decoded Move
+	assert.True(t, json.Unmarshal(data, &decoded) == nil)
+	assert.Equals(t, decoded, move)
+
+}
+
+func TestMoveJSONMultiJump(t *testing.T) {
+
+	currentBoardStr := "" +
+		"|- - - - - - - -|" +
+		"|- - - - - - - -|" +
+		"|- - - - - - - -|" +
+		"|- - - - - - - -|" +
+		"|- o - o - - - -|" +
+		"|X - - - - - - -|" +
+		"|- o - o - - - -|" +
+		"|- - - - - - - -|"
+	board := NewBoard(currentBoardStr)
+
+	for _, move := range board.LegalMoves(RED_PLAYER) {
+		data, err := json.Marshal(move)
+		assert.True(t, err == nil)
+
+		var decoded Move
+		assert.True(t, json.Unmarshal(data, &decoded) == nil)
+		assert.Equals(t, decoded, move)
+		assert.Equals(t, len(decoded.Captured()), 4)
+		assert.Equals(t, len(decoded.Submoves()), 4)
+		assert.True(t, board.ApplyMove(RED_PLAYER, decoded) == board.ApplyMove(RED_PLAYER, move))
+	}
+
+	// a single jump, with the captured square but no submoves
+	currentBoardStr = "" +
+		"|- - - - - - - -|" +
+		"|- - - - - - - -|" +
+		"|- - - - - - - -|" +
+		"|- - - - - - - -|" +
+		"|- o - - - - - -|" +
+		"|x - - - - - - -|" +
+		"|- - - - - - - -|" +
+		"|- - - - - - - -|"
+	board = NewBoard(currentBoardStr)
+	data := `{"from":{"row":5,"col":0},"to":{"row":3,"col":2},"captured":[{"row":4,"col":1}]}`
+	var decoded Move
+	assert.True(t, json.Unmarshal([]byte(data), &decoded) == nil)
+	assert.Equals(t, decoded.Captured(), []Location{NewLocation(4, 1)})
+	assert.Equals(t, decoded.Notation(), "21x14")
+	assert.True(t, board.ApplyMove(RED_PLAYER, decoded).PieceAt(NewLocation(4, 1)) == EMPTY)
+
+	data = `{"from":{"row":5,"col":0},"to":{"row":1,"col":0},"submoves":[{"from":{"row":5,"col":0},"to":{"row":3,"col":2}}]}`
+	assert.True(t, json.Unmarshal([]byte(data), &decoded) != nil)
+
+	// submoves which don't continue from each other
+	data = `{"from":{"row":5,"col":0},"to":{"row":1,"col":4},"submoves":[` +
+		`{"from":{"row":5,"col":0},"to":{"row":3,"col":2},"captured":[{"row":4,"col":1}]},` +
+		`{"from":{"row":3,"col":0},"to":{"row":1,"col":4},"captured":[{"row":2,"col":3}]}]}`
+	assert.True(t, json.Unmarshal([]byte(data), &decoded) != nil)
+
+	// captured squares in a different order to the submoves
+	data = `{"from":{"row":5,"col":0},"to":{"row":1,"col":4},"captured":[{"row":2,"col":3},{"row":4,"col":1}],"submoves":[` +
+		`{"from":{"row":5,"col":0},"to":{"row":3,"col":2},"captured":[{"row":4,"col":1}]},` +
+		`{"from":{"row":3,"col":2},"to":{"row":1,"col":4},"captured":[{"row":2,"col":3}]}]}`
+	assert.True(t, json.Unmarshal([]byte(data), &decoded) != nil)
+	data = strings.Replace(data, `"captured":[{"row":2,"col":3},{"row":4,"col":1}]`, `"captured":[{"row":4,"col":1},{"row":2,"col":3}]`, 1)
+	assert.True(t, json.Unmarshal([]byte(data), &decoded) == nil)
+	assert.Equals(t, decoded.Captured(), []Location{NewLocation(4, 1), NewLocation(2, 3)})
+
+}
+
+func TestMoveJSONFlyingKing(t *testing.T) {
+
+	// a russian king capturing from a distance
+	position, _ := ParseFEN("W:WK29:B18,9")
+	position.Variant = RUSSIAN_VARIANT
+	moves := position.LegalMoves()
+	assert.True(t, len(moves) > 0)
+	for _, move := range moves {
+		data, err := json.Marshal(move)
+		assert.True(t, err == nil)
+
+		var decoded Move
+		assert.True(t, json.Unmarshal(data, &decoded) == nil)
+		assert.Equals(t, decoded, move)
+		assert.Equals(t, decoded.Captured(), move.Captured())
+		assert.Equals(t, decoded.Notation(), move.Notation())
+		assert.Equals(t, position.ApplyMove(decoded), position.ApplyMove(move))
+		assert.Equals(t, position.ApplyMove(decoded).Board.PieceAt(NewLocation(4, 3)), EMPTY)
+	}
+
+	// and sliding two squares, which is not a jump
+	position, _ = ParseFEN("W:WK29:B3")
+	position.Variant = RUSSIAN_VARIANT
+	move, err := position.ParseMove("29-22")
+	assert.True(t, err == nil)
+	data, _ := json.Marshal(move)
+	var decoded Move
+	assert.True(t, json.Unmarshal(data, &decoded) == nil)
+	assert.Equals(t, decoded, move)
+	assert.False(t, decoded.IsJump())
+	assert.Equals(t, decoded.Notation(), "29-22")
+	assert.Equals(t, position.ApplyMove(decoded), position.ApplyMove(move))
+
+}
@@ -5,13 +5,26 @@ type Location struct {
 	col int
 }
 
+// The four diagonal directions, as row and column offsets
+var diagonals = []Location{
+	{row: 1, col: 1},
+	{row: -1, col: 1},
+	{row: 1, col: -1},
+	{row: -1, col: -1},
+}
+
 func NewLocation(row, col int) Location {
 	return Location{row: row, col: col}
 }
 
 func (loc Location) isOffBoard() bool {
+	return loc.isOffBoardOfSize(8)
+}
 
-	if loc.row < 0 || loc.row >= 8 || loc.col < 0 || loc.col >= 8 {
+// Whether the location is off a board with size rows and columns
+func (loc Location) isOffBoardOfSize(size int) bool {
+
+	if loc.row < 0 || loc.row >= size || loc.col < 0 || loc.col >= size {
 		return true
 	}
 	return false
@@ -29,3 +42,25 @@ func (loc Location) Col() int {
 func (loc Location) Equals(otherLoc Location) bool {
 	return loc.row == otherLoc.row && loc.col == otherLoc.col
 }
+
+// The row direction a player's men move in
+func forwardRow(player Player) int {
+	if player == BLACK_PLAYER {
+		return 1
+	}
+	return -1
+}
+
+// The row on which a player's men are promoted, on a board of the given
+// size
+func promotionRow(player Player, size int) int {
+	if player == BLACK_PLAYER {
+		return size - 1
+	}
+	return 0
+}
+
+// The next location in the given diagonal direction
+func (loc Location) step(direction Location) Location {
+	return Location{row: loc.row + direction.row, col: loc.col + direction.col}
+}
@@ -8,6 +8,10 @@ import (
 type Move struct {
 	from Location
 	to   Location
+
+	// the location of the captured piece, for a single jump.  Left as the
+	// zero Location, which is never a playable square, when nothing is
+	// captured.
 	over Location
 
 	// if a move contains submoves, this means it was a double/triple/etc
@@ -17,6 +21,10 @@ type Move struct {
 	submoves []Move
 }
 
+// A move along with the board after it was made.
+//
+// Deprecated: only used by the old jump generator, which has been replaced
+// by the Variant move generator.  Kept so existing code still compiles.
 type BoardMove struct {
 	board            Board
 	move             Move
@@ -54,11 +62,12 @@ func NewMove(moveSequence []Move) Move {
 
 }
 
+// Whether the move captures any pieces
 func (move Move) IsJump() bool {
 	if len(move.submoves) > 0 {
 		return true
 	}
-	return (move.from.row-move.to.row == 2 || move.from.row-move.to.row == -2)
+	return move.over != Location{}
 }
 
 func (move Move) IsInitialized() bool {
@@ -119,6 +128,29 @@ func (move Move) To() Location {
 	return move.to
 }
 
+// The locations of the pieces captured by this move, in the order
+// they were jumped.
+func (move Move) Captured() []Location {
+	captured := []Location{}
+	if len(move.submoves) > 0 {
+		for _, submove := range move.submoves {
+			captured = append(captured, submove.over)
+		}
+	} else if move.IsJump() {
+		captured = append(captured, move.over)
+	}
+	return captured
+}
+
+// The individual jumps making up a multiple jump, or nil for a
+// single move or jump.
+func (move Move) Submoves() []Move {
+	if len(move.submoves) == 0 {
+		return nil
+	}
+	return append([]Move{}, move.submoves...)
+}
+
 func (move Move) String() string {
 	return move.compactString()
 }
@@ -23,10 +23,27 @@ func TestIsJumpNegCase(t *testing.T) {
 func TestIsJumpEasy(t *testing.T) {
 	move := Move{
 		from: Location{row: 4, col: 0},
+		over: Location{row: 3, col: 1},
 		to:   Location{row: 2, col: 2},
 	}
 	assert.True(t, move.IsJump())
 
+	// a flying king moving two squares without capturing
+	move = Move{
+		from: Location{row: 4, col: 0},
+		to:   Location{row: 2, col: 2},
+	}
+	assert.False(t, move.IsJump())
+
+	// and capturing a piece three squares away
+	move = Move{
+		from: Location{row: 7, col: 0},
+		over: Location{row: 4, col: 3},
+		to:   Location{row: 3, col: 4},
+	}
+	assert.True(t, move.IsJump())
+	assert.Equals(t, move.Captured(), []Location{{row: 4, col: 3}})
+
 }
 
 func TestIsJumpHard(t *testing.T) {
@@ -64,3 +81,30 @@ func TestIsJumpHard2(t *testing.T) {
 	assert.True(t, move.IsJump())
 
 }
+
+func TestCaptured(t *testing.T) {
+
+	move := Move{
+		from: Location{row: 5, col: 0},
+		to:   Location{row: 4, col: 1},
+	}
+	assert.Equals(t, len(move.Captured()), 0)
+	assert.True(t, move.Submoves() == nil)
+
+	submove1 := Move{
+		from: Location{row: 5, col: 0},
+		over: Location{row: 4, col: 1},
+		to:   Location{row: 3, col: 2},
+	}
+	assert.Equals(t, submove1.Captured(), []Location{submove1.over})
+
+	submove2 := Move{
+		from: Location{row: 3, col: 2},
+		over: Location{row: 2, col: 1},
+		to:   Location{row: 1, col: 0},
+	}
+	move = NewMove([]Move{submove1, submove2})
+	assert.Equals(t, move.Captured(), []Location{submove1.over, submove2.over})
+	assert.Equals(t, len(move.Submoves()), 2)
+
+}
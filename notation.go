@@ -0,0 +1,160 @@
+package checkerscore
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+/*
+Standard checkers notation numbers the 32 playable squares from 1 to 32,
+starting in the top left of the board (black's side) and reading left to
+right, top to bottom:
+
+		"|-  1 -  2 -  3 -  4|"
+		"| 5 -  6 -  7 -  8 -|"
+		"|-  9 - 10 - 11 - 12|"
+		"|13 - 14 - 15 - 16 -|"
+		"|- 17 - 18 - 19 - 20|"
+		"|21 - 22 - 23 - 24 -|"
+		"|- 25 - 26 - 27 - 28|"
+		"|29 - 30 - 31 - 32 -|"
+
+Moves are written as "11-15", and jumps as "15x24" or, for multiple
+jumps, "15x24x31" listing every square the piece lands on.
+*/
+
+// Returns the standard square number (1-32) of this location, or 0 if
+// the location is not a playable square.
+func (loc Location) SquareNumber() int {
+	if loc.isOffBoard() || !loc.isDarkSquare() {
+		return 0
+	}
+	return loc.row*4 + loc.col/2 + 1
+}
+
+// Returns the location of the given standard square number (1-32)
+func NewLocationFromSquareNumber(square int) (Location, error) {
+	if square < 1 || square > 32 {
+		return Location{}, fmt.Errorf("invalid square number: %d", square)
+	}
+	index := square - 1
+	row := index / 4
+	col := (index % 4) * 2
+	if row%2 == 0 {
+		col += 1
+	}
+	return Location{row: row, col: col}, nil
+}
+
+func (loc Location) isDarkSquare() bool {
+	return (loc.row+loc.col)%2 == 1
+}
+
+// The squares visited by a move, starting with the from location and
+// followed by every square the piece lands on.
+func (move Move) path() []Location {
+	path := []Location{move.from}
+	if len(move.submoves) == 0 {
+		return append(path, move.to)
+	}
+	for _, submove := range move.submoves {
+		path = append(path, submove.to)
+	}
+	return path
+}
+
+// Serialize a move in standard notation, eg "11-15" or "15x24x31"
+func (move Move) Notation() string {
+	return ENGLISH_VARIANT.MoveNotation(move)
+}
+
+// Find the legal move for player described by the given standard notation.
+// Jumps may be given either in full ("15x24x31") or with only the from and
+// to squares ("15x31"), as long as that is not ambiguous.
+func (board Board) ParseMove(player Player, notation string) (Move, error) {
+
+	squares, err := parseNotationSquares(notation)
+	if err != nil {
+		return Move{}, err
+	}
+
+	return matchNotation(board.LegalMoves(player), squares, notation)
+
+}
+
+// Find the single move among moves visiting the given squares
+func matchNotation(moves []Move, squares []Location, notation string) (Move, error) {
+
+	matches := []Move{}
+	for _, move := range moves {
+		if moveMatchesSquares(move, squares) {
+			matches = append(matches, move)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return Move{}, fmt.Errorf("illegal move: %v", notation)
+	case 1:
+		return matches[0], nil
+	default:
+		return Move{}, fmt.Errorf("ambiguous move: %v", notation)
+	}
+
+}
+
+func parseNotationSquares(notation string) ([]Location, error) {
+	return parseNotationSquaresWith(notation, NewLocationFromSquareNumber)
+}
+
+// Parse the squares in a move, using locate to convert square numbers
+func parseNotationSquaresWith(notation string, locate func(int) (Location, error)) ([]Location, error) {
+
+	notation = strings.TrimSpace(notation)
+	fields := strings.FieldsFunc(notation, func(r rune) bool {
+		return r == '-' || r == 'x' || r == 'X' || r == ':'
+	})
+	if len(fields) < 2 {
+		return nil, fmt.Errorf("invalid move notation: %q", notation)
+	}
+
+	squares := []Location{}
+	for _, field := range fields {
+		square, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid move notation: %q", notation)
+		}
+		loc, err := locate(square)
+		if err != nil {
+			return nil, err
+		}
+		squares = append(squares, loc)
+	}
+	return squares, nil
+
+}
+
+func moveMatchesSquares(move Move, squares []Location) bool {
+
+	path := move.path()
+	if !path[0].Equals(squares[0]) {
+		return false
+	}
+	if !path[len(path)-1].Equals(squares[len(squares)-1]) {
+		return false
+	}
+	if len(squares) == 2 {
+		return true
+	}
+	if len(squares) != len(path) {
+		return false
+	}
+	for i, loc := range squares {
+		if !path[i].Equals(loc) {
+			return false
+		}
+	}
+	return true
+
+}
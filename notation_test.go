@@ -0,0 +1,71 @@
+package checkerscore
+
+import (
+	"github.com/couchbaselabs/go.assert"
+	"testing"
+)
+
+func TestSquareNumber(t *testing.T) {
+	assert.Equals(t, Location{row: 0, col: 1}.SquareNumber(), 1)
+	assert.Equals(t, Location{row: 1, col: 0}.SquareNumber(), 5)
+	assert.Equals(t, Location{row: 7, col: 6}.SquareNumber(), 32)
+	assert.Equals(t, Location{row: 0, col: 0}.SquareNumber(), 0)
+
+	for square := 1; square <= 32; square++ {
+		loc, err := NewLocationFromSquareNumber(square)
+		assert.True(t, err == nil)
+		assert.Equals(t, loc.SquareNumber(), square)
+	}
+
+	_, err := NewLocationFromSquareNumber(33)
+	assert.True(t, err != nil)
+
+}
+
+func TestParseMove(t *testing.T) {
+
+	board := NewStartingBoard()
+	move, err := board.ParseMove(BLACK_PLAYER, "11-15")
+	assert.True(t, err == nil)
+	assert.Equals(t, move.From(), Location{row: 2, col: 5})
+	assert.Equals(t, move.To(), Location{row: 3, col: 4})
+	assert.Equals(t, move.Notation(), "11-15")
+
+	_, err = board.ParseMove(BLACK_PLAYER, "11-16")
+	assert.True(t, err == nil)
+
+	_, err = board.ParseMove(BLACK_PLAYER, "1-5")
+	assert.True(t, err != nil)
+
+	_, err = board.ParseMove(RED_PLAYER, "11-15")
+	assert.True(t, err != nil)
+
+	_, err = board.ParseMove(BLACK_PLAYER, "eleven-fifteen")
+	assert.True(t, err != nil)
+
+}
+
+func TestParseMoveMultiJump(t *testing.T) {
+
+	currentBoardStr := "" +
+		"|- - - - - - - -|" +
+		"|- - - - - - - -|" +
+		"|- - - - - - - -|" +
+		"|- - - - - - - -|" +
+		"|- o - o - - - -|" +
+		"|X - - - - - - -|" +
+		"|- o - o - - - -|" +
+		"|- - - - - - - -|"
+	board := NewBoard(currentBoardStr)
+
+	// the king can go around the square in either direction, so
+	// the short form is ambiguous
+	_, err := board.ParseMove(RED_PLAYER, "21x21")
+	assert.True(t, err != nil)
+
+	move, err := board.ParseMove(RED_PLAYER, "21x14x23x30x21")
+	assert.True(t, err == nil)
+	assert.Equals(t, move.Notation(), "21x14x23x30x21")
+	assert.True(t, move.IsJump())
+
+}
@@ -0,0 +1,277 @@
+package checkerscore
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+/*
+Reader for games in Portable Draughts Notation (PDN), eg:
+
+	[Event "Casual game"]
+	[Black "checkerlution"]
+	[White "checkers-bot-minimax"]
+	[Result "1-0"]
+
+	1. 11-15 23-19 2. 8-11 22-17 {a comment} 3. 9-13 17x10 ... 1-0
+
+Black (the "o" pieces on squares 1-12) moves first, and results are
+written from black's point of view, so "1-0" (or "2-0") is a black win.
+Comments and variations are skipped.
+*/
+
+// The tags written first by WritePDN, in this order
+var pdnStandardTags = []string{"Event", "Site", "Date", "Round", "Black", "White", "Result"}
+
+// The maximum length of a line of movetext written by WritePDN
+const pdnLineLength = 80
+
+type PDNGame struct {
+	Tags   map[string]string
+	Moves  []string // in standard notation, eg "11-15"
+	Result GameResult
+}
+
+func ReadPDN(reader io.Reader) ([]PDNGame, error) {
+
+	games := []PDNGame{}
+	game := newPDNGame()
+	inMovetext := false
+	commentDepth := 0
+	variationDepth := 0
+
+	finishGame := func() {
+		if len(game.Tags) > 0 || len(game.Moves) > 0 {
+			games = append(games, game)
+		}
+		game = newPDNGame()
+		inMovetext = false
+	}
+
+	scanner := bufio.NewScanner(reader)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber += 1
+		line := strings.TrimSpace(scanner.Text())
+
+		if commentDepth == 0 && strings.HasPrefix(line, "[") {
+			if inMovetext {
+				// a game without a result token, the next one begins
+				finishGame()
+			}
+			name, value, err := parsePDNTag(line)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", lineNumber, err)
+			}
+			game.Tags[name] = value
+			if name == "Result" {
+				game.Result = parsePDNResult(value)
+			}
+			continue
+		}
+
+		for _, token := range tokenizePDNMovetext(line) {
+			switch {
+			case token == "{":
+				commentDepth += 1
+			case token == "}":
+				commentDepth -= 1
+			case commentDepth > 0:
+				continue
+			case token == "(":
+				variationDepth += 1
+			case token == ")":
+				variationDepth -= 1
+			case variationDepth > 0:
+				continue
+			case isPDNResult(token):
+				game.Result = parsePDNResult(token)
+				finishGame()
+			case isPDNMoveNumber(token):
+				inMovetext = true
+			default:
+				inMovetext = true
+				game.Moves = append(game.Moves, token)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	finishGame()
+
+	return games, nil
+
+}
+
+// Replay the game from the standard starting position, calling visit with
+// the board and player to move before each move is applied.
+func (game PDNGame) Replay(visit func(board Board, player Player, move Move)) error {
+
+	board := NewStartingBoard()
+	player := BLACK_PLAYER
+
+	for i, notation := range game.Moves {
+		move, err := board.ParseMove(player, notation)
+		if err != nil {
+			return fmt.Errorf("move %d: %v", i+1, err)
+		}
+		if visit != nil {
+			visit(board, player, move)
+		}
+		board = board.ApplyMove(player, move)
+		player = player.Opponent()
+	}
+	return nil
+
+}
+
+// Write games in PDN.  The standard tags come first, followed by any others
+// sorted by name, and the Result tag always matches game.Result.
+func WritePDN(writer io.Writer, games []PDNGame) error {
+
+	buffer := bufio.NewWriter(writer)
+	for i, game := range games {
+		if i > 0 {
+			buffer.WriteString("\n")
+		}
+
+		tags := map[string]string{}
+		for name, value := range game.Tags {
+			tags[name] = value
+		}
+		tags["Result"] = pdnResultToken(game.Result)
+
+		names := []string{}
+		for name := range tags {
+			if !isPDNStandardTag(name) {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		for _, name := range append(append([]string{}, pdnStandardTags...), names...) {
+			if value, ok := tags[name]; ok {
+				fmt.Fprintf(buffer, "[%v %q]\n", name, value)
+			}
+		}
+		buffer.WriteString("\n")
+
+		line := bytes.Buffer{}
+		addToken := func(token string) {
+			if line.Len() > 0 && line.Len()+1+len(token) > pdnLineLength {
+				buffer.WriteString(line.String() + "\n")
+				line.Reset()
+			}
+			if line.Len() > 0 {
+				line.WriteString(" ")
+			}
+			line.WriteString(token)
+		}
+		for j, move := range game.Moves {
+			if j%2 == 0 {
+				addToken(fmt.Sprintf("%d.", j/2+1))
+			}
+			addToken(move)
+		}
+		addToken(pdnResultToken(game.Result))
+		buffer.WriteString(line.String() + "\n")
+	}
+	return buffer.Flush()
+
+}
+
+func isPDNStandardTag(name string) bool {
+	for _, standard := range pdnStandardTags {
+		if name == standard {
+			return true
+		}
+	}
+	return false
+}
+
+func pdnResultToken(result GameResult) string {
+	switch result {
+	case BLACK_WINS:
+		return "1-0"
+	case RED_WINS:
+		return "0-1"
+	case DRAW:
+		return "1/2-1/2"
+	default:
+		return "*"
+	}
+}
+
+func newPDNGame() PDNGame {
+	return PDNGame{Tags: map[string]string{}}
+}
+
+func parsePDNTag(line string) (name, value string, err error) {
+	if !strings.HasSuffix(line, "]") {
+		return "", "", fmt.Errorf("invalid tag: %v", line)
+	}
+	contents := strings.TrimSpace(line[1 : len(line)-1])
+	fields := strings.SplitN(contents, " ", 2)
+	if len(fields) != 2 {
+		return "", "", fmt.Errorf("invalid tag: %v", line)
+	}
+	name = fields[0]
+	value = strings.Trim(strings.TrimSpace(fields[1]), "\"")
+	return name, value, nil
+}
+
+// Split a line of movetext into tokens, treating comment and variation
+// delimiters as tokens of their own.
+func tokenizePDNMovetext(line string) []string {
+	tokens := []string{}
+	current := []rune{}
+	flush := func() {
+		if len(current) > 0 {
+			tokens = append(tokens, string(current))
+			current = []rune{}
+		}
+	}
+	for _, r := range line {
+		switch {
+		case unicode.IsSpace(r):
+			flush()
+		case r == '{' || r == '}' || r == '(' || r == ')':
+			flush()
+			tokens = append(tokens, string(r))
+		default:
+			current = append(current, r)
+		}
+	}
+	flush()
+	return tokens
+}
+
+func isPDNMoveNumber(token string) bool {
+	return strings.HasSuffix(token, ".")
+}
+
+func isPDNResult(token string) bool {
+	switch token {
+	case "1-0", "0-1", "2-0", "0-2", "1-1", "1/2-1/2", "*":
+		return true
+	}
+	return false
+}
+
+func parsePDNResult(token string) GameResult {
+	switch token {
+	case "1-0", "2-0":
+		return BLACK_WINS
+	case "0-1", "0-2":
+		return RED_WINS
+	case "1-1", "1/2-1/2":
+		return DRAW
+	default:
+		return UNKNOWN_RESULT
+	}
+}
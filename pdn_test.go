@@ -0,0 +1,106 @@
+package checkerscore
+
+import (
+	"bytes"
+	"github.com/couchbaselabs/go.assert"
+	"strings"
+	"testing"
+)
+
+const testPDN = `
+[Event "Test game one"]
+[Black "checkerlution"]
+[White "checkers-bot-minimax"]
+[Result "1-0"]
+
+1. 11-15 23-19 2. 8-11 {the Old Fourteenth} 22-17 (2. ... 22-18)
+3. 4-8 17-13 1-0
+
+[Event "Test game two"]
+[Result "1/2-1/2"]
+
+1. 11-15 24-19 2. 15x24 28x19 1/2-1/2
+`
+
+func TestReadPDN(t *testing.T) {
+
+	games, err := ReadPDN(strings.NewReader(testPDN))
+	assert.True(t, err == nil)
+	assert.Equals(t, len(games), 2)
+
+	game := games[0]
+	assert.Equals(t, game.Tags["Black"], "checkerlution")
+	assert.Equals(t, game.Result, BLACK_WINS)
+	assert.Equals(t, game.Moves, []string{"11-15", "23-19", "8-11", "22-17", "4-8", "17-13"})
+
+	game = games[1]
+	assert.Equals(t, game.Result, DRAW)
+	assert.Equals(t, len(game.Moves), 4)
+
+}
+
+func TestPDNReplay(t *testing.T) {
+
+	games, err := ReadPDN(strings.NewReader(testPDN))
+	assert.True(t, err == nil)
+
+	players := []Player{}
+	var lastBoard Board
+	var lastMove Move
+	visit := func(board Board, player Player, move Move) {
+		players = append(players, player)
+		lastBoard = board
+		lastMove = move
+	}
+	err = games[1].Replay(visit)
+	assert.True(t, err == nil)
+	assert.Equals(t, players, []Player{BLACK_PLAYER, RED_PLAYER, BLACK_PLAYER, RED_PLAYER})
+	assert.True(t, lastMove.IsJump())
+	assert.Equals(t, lastBoard.ApplyMove(RED_PLAYER, lastMove).WeightedScore(RED_PLAYER), 0.0)
+
+	game := PDNGame{Moves: []string{"11-15", "11-15"}}
+	assert.True(t, game.Replay(nil) != nil)
+
+}
+
+func TestWritePDN(t *testing.T) {
+
+	games, err := ReadPDN(strings.NewReader(testPDN))
+	assert.True(t, err == nil)
+	games[0].Tags["Opening"] = "Old Fourteenth"
+
+	buffer := bytes.Buffer{}
+	assert.True(t, WritePDN(&buffer, games) == nil)
+	assert.Equals(t, buffer.String(), `[Event "Test game one"]
+[Black "checkerlution"]
+[White "checkers-bot-minimax"]
+[Result "1-0"]
+[Opening "Old Fourteenth"]
+
+1. 11-15 23-19 2. 8-11 22-17 3. 4-8 17-13 1-0
+
+[Event "Test game two"]
+[Result "1/2-1/2"]
+
+1. 11-15 24-19 2. 15x24 28x19 1/2-1/2
+`)
+
+	reread, err := ReadPDN(&buffer)
+	assert.True(t, err == nil)
+	assert.Equals(t, reread, games)
+
+	// long games are wrapped
+	long := PDNGame{Moves: []string{}}
+	for i := 0; i < 40; i++ {
+		long.Moves = append(long.Moves, "11-15")
+	}
+	buffer.Reset()
+	assert.True(t, WritePDN(&buffer, []PDNGame{long}) == nil)
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	assert.Equals(t, lines[0], `[Result "*"]`)
+	assert.Equals(t, len(lines), 6)
+	for _, line := range lines {
+		assert.True(t, len(line) <= 80)
+	}
+
+}
@@ -11,19 +11,21 @@ const (
 	BLACK_KING
 )
 
+// The character used in compact board strings.  See Glyph for the
+// unicode equivalent.
 func (piece Piece) String() string {
 
 	switch piece {
 	case EMPTY:
 		return "-"
 	case BLACK:
-		return "o" // TODO: use unicode ●
+		return "o"
 	case BLACK_KING:
-		return "O" // TODO: use unicode ♚
+		return "O"
 	case RED:
-		return "x" // TODO: use unicode ○
+		return "x"
 	case RED_KING:
-		return "X" // TODO: use unicode ♔
+		return "X"
 	}
 	panic("Unknown piece")
 
@@ -77,6 +79,17 @@ func (piece Piece) OwnedBy(player Player) bool {
 	}
 }
 
+// The player who owns this piece.  Panics if the piece is EMPTY.
+func (piece Piece) Owner() Player {
+	switch piece {
+	case BLACK, BLACK_KING:
+		return BLACK_PLAYER
+	case RED, RED_KING:
+		return RED_PLAYER
+	}
+	panic("Empty square has no owner")
+}
+
 func (piece Piece) IsKing() bool {
 	switch piece {
 	case BLACK:
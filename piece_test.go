@@ -18,3 +18,11 @@ func TestKing(t *testing.T) {
 	assert.Equals(t, RED.King(), RED_KING)
 
 }
+
+func TestOwner(t *testing.T) {
+	assert.Equals(t, BLACK.Owner(), BLACK_PLAYER)
+	assert.Equals(t, BLACK_KING.Owner(), BLACK_PLAYER)
+	assert.Equals(t, RED.Owner(), RED_PLAYER)
+	assert.Equals(t, RED_KING.Owner(), RED_PLAYER)
+
+}
@@ -15,3 +15,12 @@ func (player Player) Opponent() Player {
 		return RED_PLAYER
 	}
 }
+
+func (player Player) String() string {
+	switch player {
+	case RED_PLAYER:
+		return "red"
+	default:
+		return "black"
+	}
+}
@@ -0,0 +1,19 @@
+package checkerscore
+
+/*
+American Pool checkers: men capture backwards and kings fly, but any
+capture may be chosen regardless of how many pieces it takes.  A man
+reaching the last row during a capture carries on capturing as a man,
+and is only promoted if its move ends there.  The pieces start on the
+same squares as in English checkers, and black moves first.
+*/
+var POOL_VARIANT = &Variant{
+	Name:                "pool",
+	MenCaptureBackwards: true,
+	FlyingKings:         true,
+}
+
+func init() {
+	registerVariant(POOL_VARIANT)
+	registerStartingPosition("pool", englishStartingBoard, BLACK_PLAYER)
+}
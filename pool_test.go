@@ -0,0 +1,32 @@
+package checkerscore
+
+import (
+	"github.com/couchbaselabs/go.assert"
+	"testing"
+)
+
+func TestPoolPerft(t *testing.T) {
+	position := POOL_VARIANT.NewStartingPosition()
+	assert.Equals(t, position.Player, BLACK_PLAYER)
+	expected := []int{7, 49, 302, 1469, 7482, 37986}
+	for i, count := range expected {
+		assert.Equals(t, position.Perft(i+1), count)
+	}
+}
+
+func TestPoolCaptures(t *testing.T) {
+
+	// any capture can be chosen
+	position, _ := ParseFEN("W:W17,31:B22,14,7,27")
+	position.Variant = POOL_VARIANT
+	assert.Equals(t, sortedNotations(position.LegalMoves()), []string{"17x10x3", "17x26", "31x24"})
+
+	// unlike russian draughts, a man passing over the last row is not
+	// promoted
+	position, _ = ParseFEN("W:W11:B7,6")
+	position.Variant = POOL_VARIANT
+	moves := position.LegalMoves()
+	assert.Equals(t, sortedNotations(moves), []string{"11x2x9"})
+	assert.Equals(t, position.ApplyMove(moves[0]).Board.PieceAt(moves[0].To()), RED)
+
+}
@@ -0,0 +1,58 @@
+package checkerscore
+
+// A board along with the player whose turn it is to move, and the rules
+// being played.  A nil Variant plays English checkers.
+type Position struct {
+	Board   Board
+	Player  Player
+	Variant *Variant
+}
+
+// The standard starting position, with black to move.
+func NewStartingPosition() Position {
+	return Position{Board: NewStartingBoard(), Player: BLACK_PLAYER}
+}
+
+func (position Position) LegalMoves() []Move {
+	return position.Board.LegalMovesForVariant(position.Player, position.Variant)
+}
+
+// The position after the player to move makes move.
+func (position Position) ApplyMove(move Move) Position {
+	return Position{
+		Board:   position.Board.ApplyMoveForVariant(position.Player, move, position.Variant),
+		Player:  position.Player.Opponent(),
+		Variant: position.Variant,
+	}
+}
+
+// Find the legal move described by the given notation, as for
+// Board.ParseMove, using the variant's square numbers
+func (position Position) ParseMove(notation string) (Move, error) {
+	squares, err := parseNotationSquaresWith(notation, position.Variant.LocationOfSquare)
+	if err != nil {
+		return Move{}, err
+	}
+	return matchNotation(position.LegalMoves(), squares, notation)
+}
+
+// Serialize a move in the variant's notation
+func (position Position) MoveNotation(move Move) string {
+	return position.Variant.MoveNotation(move)
+}
+
+// Count the leaf nodes of the move tree to the given depth
+func (position Position) Perft(depth int) int {
+	if depth == 0 {
+		return 1
+	}
+	moves := position.LegalMoves()
+	if depth == 1 {
+		return len(moves)
+	}
+	total := 0
+	for _, move := range moves {
+		total += position.ApplyMove(move).Perft(depth - 1)
+	}
+	return total
+}
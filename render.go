@@ -0,0 +1,160 @@
+package checkerscore
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// How pieces are drawn by Board.Render
+type RenderStyle int
+
+const (
+	ASCII_STYLE   = RenderStyle(iota) // the compact string characters, eg "o" and "X"
+	UNICODE_STYLE                     // ●, ♚, ○ and ♔
+)
+
+const (
+	ansiReset       = "\x1b[0m"
+	ansiLight       = "\x1b[47m"
+	ansiDark        = "\x1b[42m"
+	ansiMoved       = "\x1b[43m"
+	ansiCaptured    = "\x1b[45m"
+	ansiRedPiece    = "\x1b[1;31m"
+	ansiBlackPiece  = "\x1b[1;30m"
+	ansiCoordinates = "\x1b[2m"
+)
+
+type RenderOptions struct {
+	Style         RenderStyle
+	Color         bool     // draw the squares and pieces with ANSI colors
+	Coordinates   bool     // label the rows and columns
+	SquareNumbers bool     // list the square numbers alongside each row
+	Highlight     *Move    // mark the from, to and captured squares of a move
+	Flipped       bool     // view from the black side, with black at the bottom
+	Variant       *Variant // the board's orientation and square numbers, English if nil
+}
+
+// The unicode glyph for a piece, or a space for an empty square
+func (piece Piece) Glyph() string {
+	switch piece {
+	case BLACK:
+		return "●"
+	case BLACK_KING:
+		return "♚"
+	case RED:
+		return "○"
+	case RED_KING:
+		return "♔"
+	}
+	return " "
+}
+
+/*
+Draw the board for a terminal, one row per line, eg with square numbers:
+
+	   o     o     o     o     1  2  3  4
+	o     o     o     o        5  6  7  8
+	...
+
+Without color, empty dark squares are drawn as "." and highlighted
+squares are bracketed: "[o]" for the squares a move starts and ends on
+and "(x)" for the captured squares.
+*/
+func (board Board) Render(options RenderOptions) string {
+
+	highlights := map[Location]string{}
+	if options.Highlight != nil {
+		for _, loc := range options.Highlight.Captured() {
+			highlights[loc] = ansiCaptured
+		}
+		highlights[options.Highlight.From()] = ansiMoved
+		highlights[options.Highlight.To()] = ansiMoved
+	}
+
+	order := []int{0, 1, 2, 3, 4, 5, 6, 7}
+	if options.Flipped {
+		order = []int{7, 6, 5, 4, 3, 2, 1, 0}
+	}
+
+	buffer := bytes.Buffer{}
+	if options.Coordinates {
+		buffer.WriteString(options.colorize(ansiCoordinates, "  "))
+		for _, col := range order {
+			buffer.WriteString(options.colorize(ansiCoordinates, fmt.Sprintf(" %d ", col)))
+		}
+		buffer.WriteString("\n")
+	}
+
+	for _, row := range order {
+		if options.Coordinates {
+			buffer.WriteString(options.colorize(ansiCoordinates, fmt.Sprintf("%d ", row)))
+		}
+		numbers := bytes.Buffer{}
+		for _, col := range order {
+			loc := Location{row: row, col: col}
+			buffer.WriteString(board.renderSquare(loc, highlights[loc], options))
+			if options.SquareNumbers && options.Variant.isPlayable(loc) {
+				fmt.Fprintf(&numbers, " %2d", options.Variant.SquareNumber(loc))
+			}
+		}
+		if options.SquareNumbers {
+			buffer.WriteString("  ")
+			buffer.WriteString(options.colorize(ansiCoordinates, numbers.String()))
+		}
+		buffer.WriteString("\n")
+	}
+	return buffer.String()
+
+}
+
+func (board Board) renderSquare(loc Location, highlight string, options RenderOptions) string {
+
+	piece := board.pieceAt(loc)
+	glyph := piece.String()
+	if options.Style == UNICODE_STYLE {
+		glyph = piece.Glyph()
+	}
+
+	if !options.Color {
+		if piece == EMPTY {
+			glyph = " "
+			if options.Variant.isPlayable(loc) {
+				glyph = "."
+			}
+		}
+		switch highlight {
+		case ansiMoved:
+			return "[" + glyph + "]"
+		case ansiCaptured:
+			return "(" + glyph + ")"
+		}
+		return " " + glyph + " "
+	}
+
+	if piece == EMPTY {
+		glyph = " "
+	}
+	background := ansiLight
+	if options.Variant.isPlayable(loc) {
+		background = ansiDark
+	}
+	if highlight != "" {
+		background = highlight
+	}
+	foreground := ""
+	if piece != EMPTY {
+		foreground = ansiRedPiece
+		if piece.OwnedBy(BLACK_PLAYER) {
+			foreground = ansiBlackPiece
+		}
+	}
+	return background + foreground + " " + glyph + " " + ansiReset
+
+}
+
+func (options RenderOptions) colorize(code, str string) string {
+	if !options.Color {
+		return str
+	}
+	return code + str + ansiReset
+}
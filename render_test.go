@@ -0,0 +1,63 @@
+package checkerscore
+
+import (
+	"github.com/couchbaselabs/go.assert"
+	"strings"
+	"testing"
+)
+
+func TestRenderASCII(t *testing.T) {
+
+	board := NewStartingBoard()
+	lines := strings.Split(board.Render(RenderOptions{SquareNumbers: true}), "\n")
+	assert.Equals(t, len(lines), 9)
+	assert.Equals(t, lines[0], "    o     o     o     o     1  2  3  4")
+	assert.Equals(t, lines[3], " .     .     .     .       13 14 15 16")
+	assert.Equals(t, lines[7], " x     x     x     x       29 30 31 32")
+
+	lines = strings.Split(board.Render(RenderOptions{Coordinates: true, Flipped: true}), "\n")
+	assert.Equals(t, lines[0], "   7  6  5  4  3  2  1  0 ")
+	assert.Equals(t, lines[1], "7     x     x     x     x ")
+	assert.Equals(t, lines[8], "0  o     o     o     o    ")
+
+	// the mirrored Spanish board, numbered from white's side
+	board = SPANISH_VARIANT.NewStartingPosition().Board
+	lines = strings.Split(board.Render(RenderOptions{SquareNumbers: true, Variant: SPANISH_VARIANT}), "\n")
+	assert.Equals(t, lines[0], " o     o     o     o       32 31 30 29")
+	assert.Equals(t, lines[7], "    x     x     x     x     4  3  2  1")
+
+}
+
+func TestRenderHighlight(t *testing.T) {
+
+	board, _ := ParseBoard("" +
+		"|- - - - - - - -|" +
+		"|- - - - - - - -|" +
+		"|- - - - - - - -|" +
+		"|- - - - - - - -|" +
+		"|- - - - - - - -|" +
+		"|- - o - - - - -|" +
+		"|- x - - - - - -|" +
+		"|- - - - - - - -|")
+	move, err := board.ParseMove(RED_PLAYER, "25x18")
+	assert.True(t, err == nil)
+	lines := strings.Split(board.Render(RenderOptions{Highlight: &move}), "\n")
+	assert.Equals(t, lines[5], " .    (o)    .     .    ")
+	assert.Equals(t, lines[6], "   [x]    .     .     . ")
+	assert.Equals(t, lines[4], "    .    [.]    .     . ")
+
+	colored := board.Render(RenderOptions{Highlight: &move, Color: true, Style: UNICODE_STYLE})
+	assert.True(t, strings.Contains(colored, ansiCaptured+ansiBlackPiece+" ● "))
+	assert.True(t, strings.Contains(colored, ansiMoved+ansiRedPiece+" ○ "))
+
+}
+
+func TestRenderUnicodeColor(t *testing.T) {
+
+	board := NewStartingBoard()
+	colored := board.Render(RenderOptions{Color: true, Style: UNICODE_STYLE})
+	assert.True(t, strings.Contains(colored, ansiDark+ansiBlackPiece+" ● "))
+	assert.True(t, strings.Contains(colored, ansiDark+ansiRedPiece+" ○ "))
+	assert.False(t, strings.Contains(colored, "o"))
+
+}
@@ -0,0 +1,42 @@
+package checkerscore
+
+// the possible outcomes of a game
+type GameResult int
+
+const (
+	UNKNOWN_RESULT = GameResult(iota)
+	BLACK_WINS
+	RED_WINS
+	DRAW
+)
+
+func (result GameResult) String() string {
+	switch result {
+	case BLACK_WINS:
+		return "black wins"
+	case RED_WINS:
+		return "red wins"
+	case DRAW:
+		return "draw"
+	default:
+		return "unknown"
+	}
+}
+
+// The score of the result from player's point of view: 1.0 for a win,
+// 0.5 for a draw and 0.0 for a loss or unknown result.
+func (result GameResult) ScoreFor(player Player) float64 {
+	switch result {
+	case DRAW:
+		return 0.5
+	case BLACK_WINS:
+		if player == BLACK_PLAYER {
+			return 1.0
+		}
+	case RED_WINS:
+		if player == RED_PLAYER {
+			return 1.0
+		}
+	}
+	return 0.0
+}
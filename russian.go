@@ -0,0 +1,19 @@
+package checkerscore
+
+/*
+Russian draughts: men capture backwards, kings fly, any capture may be
+chosen regardless of how many pieces it takes, and a man promoted during
+a capture carries on capturing as a king.  The pieces start on the same
+squares as in English checkers, but white (red) moves first.
+*/
+var RUSSIAN_VARIANT = &Variant{
+	Name:                "russian",
+	MenCaptureBackwards: true,
+	FlyingKings:         true,
+	PromoteMidCapture:   true,
+}
+
+func init() {
+	registerVariant(RUSSIAN_VARIANT)
+	registerStartingPosition("russian", englishStartingBoard, RED_PLAYER)
+}
@@ -0,0 +1,35 @@
+package checkerscore
+
+import (
+	"github.com/couchbaselabs/go.assert"
+	"testing"
+)
+
+func TestRussianPerft(t *testing.T) {
+	position := RUSSIAN_VARIANT.NewStartingPosition()
+	assert.Equals(t, position.Player, RED_PLAYER)
+	expected := []int{7, 49, 302, 1469, 7482, 37986}
+	for i, count := range expected {
+		assert.Equals(t, position.Perft(i+1), count)
+	}
+}
+
+func TestRussianCaptures(t *testing.T) {
+
+	// the man on 11 is promoted on 2 and carries on as a flying king,
+	// taking 6 and landing on 9 or 13
+	position, _ := ParseFEN("W:W11:B7,6")
+	position.Variant = RUSSIAN_VARIANT
+	assert.Equals(t, sortedNotations(position.LegalMoves()), []string{"11x2x13", "11x2x9"})
+
+	// any capture can be chosen, not just the one taking the most
+	position, _ = ParseFEN("W:W17,31:B22,14,7,27")
+	position.Variant = RUSSIAN_VARIANT
+	assert.Equals(t, sortedNotations(position.LegalMoves()), []string{"17x10x3", "17x26", "31x24"})
+
+	game := NewGameFromPosition(position)
+	_, err := game.PlayNotation("17x26")
+	assert.True(t, err == nil)
+	assert.Equals(t, game.Position.Variant, RUSSIAN_VARIANT)
+
+}
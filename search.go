@@ -0,0 +1,189 @@
+package checkerscore
+
+import (
+	"time"
+)
+
+/*
+Iterative deepening alpha-beta search
+
+Unlike Minimax, a player with no legal moves has lost the game, which is
+scored as -WinScore (adjusted so that quicker wins score higher), or won
+it when playing giveaway.  The search deepens one ply at a time until
+the depth or time limit is reached, and the result of the deepest
+completed iteration is returned.
+
+References:
+  - https://www.chessprogramming.org/Iterative_Deepening
+  - https://www.chessprogramming.org/Alpha-Beta
+*/
+
+// The score of a won position
+const WinScore = 1000.0
+
+// The depth limit used when SearchLimits.Depth is zero
+const maxSearchDepth = 64
+
+type SearchLimits struct {
+	Depth    int           // maximum depth in plies, or 0 for no limit
+	MoveTime time.Duration // maximum time to search, or 0 for no limit
+	Stop     chan struct{} // if not nil, closing it stops the search
+}
+
+// The result of searching to a given depth
+type SearchInfo struct {
+	Depth   int
+	Score   float64 // from the point of view of the player to move
+	PV      []Move  // principal variation, starting with the best move
+	Nodes   int
+	Elapsed time.Duration
+}
+
+type searcher struct {
+	variant  *Variant
+	eval     EvaluationFunction
+	deadline time.Time
+	stop     chan struct{}
+	canAbort bool
+	aborted  bool
+	nodes    int
+}
+
+// The best move found, or false if the player has no legal moves.
+func (info SearchInfo) BestMove() (Move, bool) {
+	if len(info.PV) == 0 {
+		return Move{}, false
+	}
+	return info.PV[0], true
+}
+
+// Search for the best move for player.  If progress is not nil, it's
+// called with the result of each completed iteration.
+func (board Board) Search(player Player, limits SearchLimits, eval EvaluationFunction, progress func(SearchInfo)) SearchInfo {
+	return Position{Board: board, Player: player}.Search(limits, eval, progress)
+}
+
+// Search for the best move for the player to move, following the rules
+// of the position's variant.
+func (position Position) Search(limits SearchLimits, eval EvaluationFunction, progress func(SearchInfo)) SearchInfo {
+
+	board, player := position.Board, position.Player
+	start := time.Now()
+	s := &searcher{variant: position.Variant, eval: eval, stop: limits.Stop}
+	if limits.MoveTime > 0 {
+		s.deadline = start.Add(limits.MoveTime)
+	}
+	maxDepth := limits.Depth
+	if maxDepth <= 0 {
+		maxDepth = maxSearchDepth
+	}
+
+	result := SearchInfo{}
+	for depth := 1; depth <= maxDepth; depth++ {
+
+		// always complete the first iteration, so there is a move to play
+		s.canAbort = depth > 1
+		score, pv := s.negamax(board, player, depth, 0, -WinScore-1, WinScore+1, result.PV)
+		if s.aborted {
+			break
+		}
+
+		result = SearchInfo{
+			Depth:   depth,
+			Score:   score,
+			PV:      pv,
+			Nodes:   s.nodes,
+			Elapsed: time.Since(start),
+		}
+		if progress != nil {
+			progress(result)
+		}
+
+		// no need to search deeper once there are no moves, or the
+		// outcome is decided
+		if len(pv) == 0 || score >= WinScore-float64(depth) || score <= -WinScore+float64(depth) {
+			break
+		}
+		if s.shouldStop() {
+			break
+		}
+	}
+	return result
+
+}
+
+func (s *searcher) negamax(board Board, player Player, depth, ply int, alpha, beta float64, previousPV []Move) (float64, []Move) {
+
+	s.nodes += 1
+	if s.canAbort && s.nodes%1024 == 0 && s.shouldStop() {
+		s.aborted = true
+	}
+	if s.aborted {
+		return 0, nil
+	}
+
+	moves := board.LegalMovesForVariant(player, s.variant)
+	if len(moves) == 0 {
+		if s.variant != nil && s.variant.Giveaway {
+			return WinScore - float64(ply), nil
+		}
+		return -WinScore + float64(ply), nil
+	}
+	if depth == 0 {
+		return s.eval(player, board), nil
+	}
+
+	// search the move from the previous iteration's principal variation first
+	if ply < len(previousPV) {
+		moves = orderPVMoveFirst(moves, previousPV[ply])
+	} else {
+		previousPV = nil
+	}
+
+	bestPV := []Move{}
+	for i, move := range moves {
+		childPV := previousPV
+		if i > 0 {
+			childPV = nil
+		}
+		score, pv := s.negamax(board.ApplyMoveForVariant(player, move, s.variant), player.Opponent(), depth-1, ply+1, -beta, -alpha, childPV)
+		score = -score
+		if s.aborted {
+			return 0, nil
+		}
+		if score > alpha || len(bestPV) == 0 {
+			bestPV = append([]Move{move}, pv...)
+		}
+		if score > alpha {
+			alpha = score
+		}
+		if alpha >= beta {
+			break
+		}
+	}
+	return alpha, bestPV
+
+}
+
+// Whether the time is up or the search has been stopped
+func (s *searcher) shouldStop() bool {
+	if !s.deadline.IsZero() && time.Now().After(s.deadline) {
+		return true
+	}
+	select {
+	case <-s.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+func orderPVMoveFirst(moves []Move, pvMove Move) []Move {
+	for i, move := range moves {
+		if move.compactString() == pvMove.compactString() {
+			ordered := append([]Move{move}, moves[:i]...)
+			return append(ordered, moves[i+1:]...)
+		}
+	}
+	return moves
+}
@@ -0,0 +1,113 @@
+package checkerscore
+
+import (
+	"github.com/couchbaselabs/go.assert"
+	"testing"
+	"time"
+)
+
+func TestSearchFindsDoubleJump(t *testing.T) {
+
+	currentBoardStr := "" +
+		"|- - - - - - o -|" +
+		"|- - - - - - - -|" +
+		"|- - - o - - - -|" +
+		"|- - x - x - - -|" +
+		"|- - - - - - - -|" +
+		"|- - - - - - x -|" +
+		"|- - - - - - - -|" +
+		"|- - - - - - - -|"
+	board := NewBoard(currentBoardStr)
+	evalFunc := DefaultEvaluationFunction()
+
+	depths := []int{}
+	progress := func(info SearchInfo) {
+		depths = append(depths, info.Depth)
+	}
+	info := board.Search(BLACK_PLAYER, SearchLimits{Depth: 3}, evalFunc, progress)
+	assert.Equals(t, depths, []int{1, 2, 3})
+	assert.Equals(t, info.Depth, 3)
+	assert.True(t, len(info.PV) > 0)
+	assert.True(t, info.Nodes > 0)
+
+	bestMove, ok := info.BestMove()
+	assert.True(t, ok)
+	assert.Equals(t, bestMove.To(), Location{row: 6, col: 7})
+
+	// the search agrees with minimax at the same depth
+	_, minimaxScore := board.Minimax(BLACK_PLAYER, 1, evalFunc)
+	info = board.Search(BLACK_PLAYER, SearchLimits{Depth: 1}, evalFunc, nil)
+	assert.Equals(t, info.Score, minimaxScore)
+
+}
+
+func TestSearchFindsWin(t *testing.T) {
+
+	// the black piece is trapped, and red wins by waiting for it
+	// to move into a capture
+	currentBoardStr := "" +
+		"|- - - - - - - o|" +
+		"|- - - - - - - -|" +
+		"|- - - - - - - -|" +
+		"|- - - - x - - -|" +
+		"|- - - - - - - -|" +
+		"|- - - - - - - -|" +
+		"|- - - - - - - -|" +
+		"|- - - - - - - -|"
+	board := NewBoard(currentBoardStr)
+	info := board.Search(RED_PLAYER, SearchLimits{Depth: 10}, DefaultEvaluationFunction(), nil)
+	assert.True(t, info.Score > WinScore-10)
+	bestMove, _ := info.BestMove()
+	assert.Equals(t, bestMove.To(), Location{row: 2, col: 5})
+
+	// a player with no moves has lost
+	currentBoardStr = "" +
+		"|- - - - - - - -|" +
+		"|- - - - - - - -|" +
+		"|- - - - - - - -|" +
+		"|- - - - x - - -|" +
+		"|- - - - - - - -|" +
+		"|- - - - - - - -|" +
+		"|- - - - - - - -|" +
+		"|- - - - - - - -|"
+	board = NewBoard(currentBoardStr)
+	info = board.Search(BLACK_PLAYER, SearchLimits{Depth: 4}, DefaultEvaluationFunction(), nil)
+	assert.Equals(t, info.Score, -WinScore)
+	_, ok := info.BestMove()
+	assert.False(t, ok)
+
+}
+
+func TestSearchMoveTime(t *testing.T) {
+
+	limits := SearchLimits{MoveTime: 50 * time.Millisecond}
+	start := time.Now()
+	info := NewStartingBoard().Search(BLACK_PLAYER, limits, DefaultEvaluationFunction(), nil)
+	assert.True(t, time.Since(start) < time.Second)
+	assert.True(t, info.Depth >= 1)
+	_, ok := info.BestMove()
+	assert.True(t, ok)
+
+}
+
+func TestSearchStop(t *testing.T) {
+
+	stop := make(chan struct{})
+	limits := SearchLimits{Stop: stop}
+	done := make(chan SearchInfo)
+	go func() {
+		done <- NewStartingBoard().Search(BLACK_PLAYER, limits, DefaultEvaluationFunction(), nil)
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	close(stop)
+
+	select {
+	case info := <-done:
+		_, ok := info.BestMove()
+		assert.True(t, ok)
+	case <-time.After(5 * time.Second):
+		t.Fatalf("search did not stop")
+	}
+
+}
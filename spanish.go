@@ -0,0 +1,38 @@
+package checkerscore
+
+/*
+Spanish draughts: kings fly, men only capture forwards, and the capture
+taking the most pieces must be played, or if several take the same
+number, one taking the most kings.  White (red) moves first.
+
+The Spanish board is the mirror image of the English one, so the pieces
+stand on the squares that are light in English checkers, and the squares
+are numbered from white's side: white starts on squares 1-12 and black
+on squares 21-32.
+*/
+var SPANISH_VARIANT = &Variant{
+	Name:           "spanish",
+	FlyingKings:    true,
+	MaximumCapture: true,
+	CaptureFilter: func(board Board, captures []Move) []Move {
+		return board.mostKingsCaptured(captures)
+	},
+	Mirrored:        true,
+	NumberedFromRed: true,
+}
+
+// Also the starting board of the other Mirrored variants
+const mirroredStartingBoard = "" +
+	"|o - o - o - o -|" +
+	"|- o - o - o - o|" +
+	"|o - o - o - o -|" +
+	"|- - - - - - - -|" +
+	"|- - - - - - - -|" +
+	"|- x - x - x - x|" +
+	"|x - x - x - x -|" +
+	"|- x - x - x - x|"
+
+func init() {
+	registerVariant(SPANISH_VARIANT)
+	registerStartingPosition("spanish", mirroredStartingBoard, RED_PLAYER)
+}
@@ -0,0 +1,63 @@
+package checkerscore
+
+import (
+	"github.com/couchbaselabs/go.assert"
+	"testing"
+)
+
+func TestSpanishPerft(t *testing.T) {
+	position := SPANISH_VARIANT.NewStartingPosition()
+	assert.Equals(t, position.Player, RED_PLAYER)
+	expected := []int{7, 49, 302, 1469, 7361, 36473, 177532}
+	for i, count := range expected {
+		assert.Equals(t, position.Perft(i+1), count)
+	}
+}
+
+func TestSpanishBoard(t *testing.T) {
+
+	// white starts on squares 1-12, on the squares that are light in
+	// English checkers
+	position := SPANISH_VARIANT.NewStartingPosition()
+	assert.Equals(t, position.FEN(), "W:W1,2,3,4,5,6,7,8,9,10,11,12:B21,22,23,24,25,26,27,28,29,30,31,32")
+	assert.True(t, position.Board.ValidateForVariant(SPANISH_VARIANT) == nil)
+	assert.False(t, position.Board.Validate() == nil)
+	assert.Equals(t, sortedPositionNotations(position), []string{
+		"10-13", "10-14", "11-14", "11-15", "12-15", "12-16", "9-13",
+	})
+
+	// square 1 is in white's right-hand corner
+	loc, err := SPANISH_VARIANT.LocationOfSquare(1)
+	assert.True(t, err == nil)
+	assert.Equals(t, loc, Location{row: 7, col: 7})
+	for square := 1; square <= 32; square++ {
+		loc, _ := SPANISH_VARIANT.LocationOfSquare(square)
+		assert.Equals(t, SPANISH_VARIANT.SquareNumber(loc), square)
+	}
+
+	parsed, err := ParseFENForVariant(position.FEN(), SPANISH_VARIANT)
+	assert.True(t, err == nil)
+	assert.Equals(t, parsed, position)
+
+	move, err := position.ParseMove("11-15")
+	assert.True(t, err == nil)
+	assert.Equals(t, move.From(), Location{row: 5, col: 3})
+
+}
+
+func spanishMoves(t *testing.T, fen string) []string {
+	position, err := ParseFENForVariant(fen, SPANISH_VARIANT)
+	assert.True(t, err == nil)
+	return sortedPositionNotations(position)
+}
+
+func TestSpanishCaptures(t *testing.T) {
+
+	// the most pieces, then the most kings
+	assert.Equals(t, spanishMoves(t, "W:W10:B13,14,22"), []string{"10x19x26"})
+	assert.Equals(t, spanishMoves(t, "W:W10:B13,K14"), []string{"10x19"})
+
+	// men don't capture backwards
+	assert.Equals(t, spanishMoves(t, "W:W13:B10"), []string{"13-17", "13-18"})
+
+}
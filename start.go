@@ -0,0 +1,105 @@
+package checkerscore
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+)
+
+// A named starting position, along with the player who moves first.
+type StartingPosition struct {
+	Name        string
+	Board       Board
+	FirstPlayer Player
+}
+
+var startingPositions = map[string]StartingPosition{}
+
+const englishStartingBoard = "" +
+	"|- o - o - o - o|" +
+	"|o - o - o - o -|" +
+	"|- o - o - o - o|" +
+	"|- - - - - - - -|" +
+	"|- - - - - - - -|" +
+	"|x - x - x - x -|" +
+	"|- x - x - x - x|" +
+	"|x - x - x - x -|"
+
+func init() {
+	registerStartingPosition("english", englishStartingBoard, BLACK_PLAYER)
+}
+
+func registerStartingPosition(name string, compactBoard string, firstPlayer Player) {
+	startingPositions[name] = StartingPosition{
+		Name:        name,
+		Board:       NewBoard(compactBoard),
+		FirstPlayer: firstPlayer,
+	}
+}
+
+// The standard starting position for English checkers, with twelve black
+// pieces on squares 1-12 and twelve red pieces on squares 21-32.  Black
+// moves first.
+func NewStartingBoard() Board {
+	return startingPositions["english"].Board
+}
+
+// Find the starting position for the variant with the given name
+func StartingPositionNamed(name string) (StartingPosition, error) {
+	startingPosition, ok := startingPositions[name]
+	if !ok {
+		return StartingPosition{}, fmt.Errorf("unknown starting position: %v", name)
+	}
+	return startingPosition, nil
+}
+
+// The names of all the available starting positions, sorted.
+func StartingPositionNames() []string {
+	names := []string{}
+	for name := range startingPositions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (board Board) IsStartingBoard() bool {
+	return board == NewStartingBoard()
+}
+
+// Check that the board matches the standard starting position, and if not
+// return an error describing every square that differs.
+func (board Board) ValidateStartingBoard() error {
+	return startingPositions["english"].ValidateBoard(board)
+}
+
+// Check that the board matches this starting position, and if not return
+// an error describing every square that differs.
+func (startingPosition StartingPosition) ValidateBoard(board Board) error {
+
+	expected := startingPosition.Board
+
+	buffer := bytes.Buffer{}
+	board.applyEachSquare(func(loc Location) {
+		if board.pieceAt(loc) == expected.pieceAt(loc) {
+			return
+		}
+		if buffer.Len() > 0 {
+			buffer.WriteString(", ")
+		}
+		fmt.Fprintf(
+			&buffer,
+			"(%d,%d): expected %v, found %v",
+			loc.row,
+			loc.col,
+			expected.pieceAt(loc),
+			board.pieceAt(loc),
+		)
+	})
+	if buffer.Len() > 0 {
+		name := startingPosition.Name
+		return fmt.Errorf("board does not match %v starting position: %v", name, buffer.String())
+	}
+	return nil
+
+}
@@ -0,0 +1,41 @@
+package checkerscore
+
+import (
+	"github.com/couchbaselabs/go.assert"
+	"testing"
+)
+
+func TestNewStartingBoard(t *testing.T) {
+
+	board := NewStartingBoard()
+	assert.True(t, board.IsStartingBoard())
+	assert.True(t, board.ValidateStartingBoard() == nil)
+	assert.Equals(t, int(board[0][1]), int(BLACK))
+	assert.Equals(t, int(board[7][0]), int(RED))
+	assert.Equals(t, len(board.LegalMoves(BLACK_PLAYER)), 7)
+
+	// modifying the returned board doesn't affect the starting position
+	board[0][1] = EMPTY
+	assert.False(t, board.IsStartingBoard())
+	assert.True(t, NewStartingBoard().IsStartingBoard())
+
+	err := board.ValidateStartingBoard()
+	assert.True(t, err != nil)
+	assert.Equals(t, err.Error(), "board does not match english starting position: (0,1): expected o, found -")
+
+}
+
+func TestStartingPositionNamed(t *testing.T) {
+
+	startingPosition, err := StartingPositionNamed("english")
+	assert.True(t, err == nil)
+	assert.Equals(t, startingPosition.FirstPlayer, BLACK_PLAYER)
+	assert.True(t, startingPosition.Board.IsStartingBoard())
+	assert.True(t, startingPosition.ValidateBoard(NewStartingBoard()) == nil)
+
+	_, err = StartingPositionNamed("chess")
+	assert.True(t, err != nil)
+
+	assert.True(t, len(StartingPositionNames()) > 0)
+
+}
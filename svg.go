@@ -0,0 +1,156 @@
+package checkerscore
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+)
+
+// Options for Board.SVG.  Zero values are replaced by the defaults.
+type SVGOptions struct {
+	Size          int      // width and height of the diagram in pixels
+	LightColor    string   // light squares
+	DarkColor     string   // dark (playable) squares
+	RedColor      string   // red pieces
+	BlackColor    string   // black pieces
+	ArrowColor    string   // move arrows
+	SquareNumbers bool     // number the dark squares
+	Move          *Move    // draw an arrow for each leg of the move
+	Flipped       bool     // view from the black side, with black at the bottom
+	Variant       *Variant // the board's orientation and square numbers, English if nil
+}
+
+var defaultSVGOptions = SVGOptions{
+	Size:       400,
+	LightColor: "#f0d9b5",
+	DarkColor:  "#b58863",
+	RedColor:   "#c0392b",
+	BlackColor: "#222222",
+	ArrowColor: "#2e86de",
+}
+
+// Draw the board as an SVG diagram
+func (board Board) SVG(options SVGOptions) string {
+	buffer := bytes.Buffer{}
+	board.WriteSVG(&buffer, options)
+	return buffer.String()
+}
+
+func (board Board) WriteSVG(w io.Writer, options SVGOptions) error {
+
+	options = options.withDefaults().escaped()
+	square := float64(options.Size) / 8
+	svg := svgWriter{w: w}
+
+	svg.printf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+		options.Size, options.Size, options.Size, options.Size)
+	svg.printf(`<defs><marker id="arrowhead" markerWidth="4" markerHeight="4" refX="2" refY="2" orient="auto" markerUnits="strokeWidth">`+
+		`<path d="M0,0 L4,2 L0,4 z" fill="%s"/></marker></defs>`+"\n", options.ArrowColor)
+
+	board.applyEachSquare(func(loc Location) {
+		x, y := options.squareOrigin(loc, square)
+		color := options.LightColor
+		if options.Variant.isPlayable(loc) {
+			color = options.DarkColor
+		}
+		svg.printf(`<rect x="%g" y="%g" width="%g" height="%g" fill="%s"/>`+"\n", x, y, square, square, color)
+		if options.SquareNumbers && options.Variant.isPlayable(loc) {
+			svg.printf(`<text x="%g" y="%g" font-size="%g" font-family="sans-serif" fill="%s">%d</text>`+"\n",
+				x+square*0.06, y+square*0.22, square*0.18, options.LightColor, options.Variant.SquareNumber(loc))
+		}
+	})
+
+	board.applyEachSquare(func(loc Location) {
+		piece := board.pieceAt(loc)
+		if piece == EMPTY {
+			return
+		}
+		cx, cy := options.squareCenter(loc, square)
+		color := options.RedColor
+		if piece.OwnedBy(BLACK_PLAYER) {
+			color = options.BlackColor
+		}
+		svg.printf(`<circle cx="%g" cy="%g" r="%g" fill="%s" stroke="#ffffff" stroke-width="%g"/>`+"\n",
+			cx, cy, square*0.38, color, square*0.03)
+		if piece.IsKing() {
+			// a crown: three points above a band
+			w, h := square*0.36, square*0.24
+			left, top := cx-w/2, cy-h/2
+			svg.printf(`<polygon points="%g,%g %g,%g %g,%g %g,%g %g,%g %g,%g %g,%g" fill="#f1c40f"/>`+"\n",
+				left, top+h, left, top, left+w/4, top+h/2, cx, top, left+3*w/4, top+h/2, left+w, top, left+w, top+h)
+		}
+	})
+
+	if options.Move != nil {
+		path := options.Move.path()
+		for i := 1; i < len(path); i++ {
+			x1, y1 := options.squareCenter(path[i-1], square)
+			x2, y2 := options.squareCenter(path[i], square)
+			svg.printf(`<line x1="%g" y1="%g" x2="%g" y2="%g" stroke="%s" stroke-width="%g" stroke-opacity="0.8" marker-end="url(#arrowhead)"/>`+"\n",
+				x1, y1, x2, y2, options.ArrowColor, square*0.1)
+		}
+	}
+
+	svg.printf("</svg>\n")
+	return svg.err
+
+}
+
+func (options SVGOptions) withDefaults() SVGOptions {
+	if options.Size <= 0 {
+		options.Size = defaultSVGOptions.Size
+	}
+	for _, field := range []struct{ value, fallback *string }{
+		{&options.LightColor, &defaultSVGOptions.LightColor},
+		{&options.DarkColor, &defaultSVGOptions.DarkColor},
+		{&options.RedColor, &defaultSVGOptions.RedColor},
+		{&options.BlackColor, &defaultSVGOptions.BlackColor},
+		{&options.ArrowColor, &defaultSVGOptions.ArrowColor},
+	} {
+		if *field.value == "" {
+			*field.value = *field.fallback
+		}
+	}
+	return options
+}
+
+// The options with the colors XML-escaped, ready to write into attributes
+func (options SVGOptions) escaped() SVGOptions {
+	for _, color := range []*string{
+		&options.LightColor,
+		&options.DarkColor,
+		&options.RedColor,
+		&options.BlackColor,
+		&options.ArrowColor,
+	} {
+		*color = html.EscapeString(*color)
+	}
+	return options
+}
+
+// The top left corner of a square in the diagram
+func (options SVGOptions) squareOrigin(loc Location, square float64) (float64, float64) {
+	row, col := loc.row, loc.col
+	if options.Flipped {
+		row, col = 7-row, 7-col
+	}
+	return float64(col) * square, float64(row) * square
+}
+
+func (options SVGOptions) squareCenter(loc Location, square float64) (float64, float64) {
+	x, y := options.squareOrigin(loc, square)
+	return x + square/2, y + square/2
+}
+
+// Writes formatted output, remembering the first error
+type svgWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (svg *svgWriter) printf(format string, args ...interface{}) {
+	if svg.err == nil {
+		_, svg.err = fmt.Fprintf(svg.w, format, args...)
+	}
+}
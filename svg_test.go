@@ -0,0 +1,67 @@
+package checkerscore
+
+import (
+	"encoding/xml"
+	"github.com/couchbaselabs/go.assert"
+	"strings"
+	"testing"
+)
+
+func TestSVG(t *testing.T) {
+
+	board := NewStartingBoard()
+	svg := board.SVG(SVGOptions{SquareNumbers: true})
+
+	// well formed, with a square for every location and a circle per piece
+	decoder := xml.NewDecoder(strings.NewReader(svg))
+	for {
+		if _, err := decoder.Token(); err != nil {
+			assert.Equals(t, err.Error(), "EOF")
+			break
+		}
+	}
+	assert.True(t, strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg" width="400" height="400"`))
+	assert.Equals(t, strings.Count(svg, "<rect "), 64)
+	assert.Equals(t, strings.Count(svg, "<circle "), 24)
+	assert.Equals(t, strings.Count(svg, "<text "), 32)
+	assert.Equals(t, strings.Count(svg, "<polygon "), 0)
+	assert.Equals(t, strings.Count(svg, "<line "), 0)
+
+	// square 1 is at the top, unless flipped
+	assert.True(t, strings.Contains(svg, `font-family="sans-serif" fill="#f0d9b5">1</text>`))
+	assert.True(t, strings.Contains(svg, `<text x="53" y="11"`))
+	flipped := board.SVG(SVGOptions{SquareNumbers: true, Flipped: true, Size: 800, DarkColor: "green"})
+	assert.True(t, strings.Contains(flipped, `<text x="606" y="722"`))
+	assert.True(t, strings.Contains(flipped, `fill="green"`))
+
+}
+
+func TestSVGMoveArrows(t *testing.T) {
+
+	position, err := ParseFEN("B:W9,18:BK5")
+	assert.True(t, err == nil)
+	move, err := position.Board.ParseMove(BLACK_PLAYER, "5x14x23")
+	assert.True(t, err == nil)
+
+	svg := position.Board.SVG(SVGOptions{Move: &move})
+	assert.Equals(t, strings.Count(svg, "<polygon "), 1)
+	assert.Equals(t, strings.Count(svg, "<line "), 2)
+	assert.True(t, strings.Contains(svg, `<line x1="25" y1="75" x2="125" y2="175"`))
+
+}
+
+func TestSVGEscapesColors(t *testing.T) {
+
+	svg := NewStartingBoard().SVG(SVGOptions{RedColor: `red"/><script>alert(1)</script>`})
+	assert.False(t, strings.Contains(svg, "<script>"))
+	assert.True(t, strings.Contains(svg, `fill="red&#34;/&gt;&lt;script&gt;alert(1)&lt;/script&gt;"`))
+
+	decoder := xml.NewDecoder(strings.NewReader(svg))
+	for {
+		if _, err := decoder.Token(); err != nil {
+			assert.Equals(t, err.Error(), "EOF")
+			break
+		}
+	}
+
+}
@@ -0,0 +1,66 @@
+package checkerscore
+
+/*
+Transforms between equivalent positions.  Rotating the board 180° and
+swapping the colors of the pieces turns a position with one player to
+move into the same position from the other player's side, so the moves
+of the flipped position are the flipped moves of the original.  Square n
+becomes square 33-n.
+*/
+
+// The piece of the same kind belonging to the other player
+func (piece Piece) SwapColor() Piece {
+	switch piece {
+	case BLACK:
+		return RED
+	case BLACK_KING:
+		return RED_KING
+	case RED:
+		return BLACK
+	case RED_KING:
+		return BLACK_KING
+	}
+	return piece
+}
+
+// The location after rotating the board 180°
+func (loc Location) Flipped() Location {
+	return Location{row: 7 - loc.row, col: 7 - loc.col}
+}
+
+// Rotate the board 180° and swap the colors of the pieces
+func (board Board) Flipped() Board {
+	flipped := Board{}
+	board.applyEachSquare(func(loc Location) {
+		to := loc.Flipped()
+		flipped[to.row][to.col] = board.pieceAt(loc).SwapColor()
+	})
+	return flipped
+}
+
+// The move on the flipped board equivalent to this one
+func (move Move) Flipped() Move {
+	flipped := Move{
+		from: move.from.Flipped(),
+		to:   move.to.Flipped(),
+	}
+	if move.over != (Location{}) {
+		flipped.over = move.over.Flipped()
+	}
+	if move.submoves != nil {
+		flipped.submoves = []Move{}
+		for _, submove := range move.submoves {
+			flipped.submoves = append(flipped.submoves, submove.Flipped())
+		}
+	}
+	return flipped
+}
+
+// The same position from the other player's side
+func (position Position) Flipped() Position {
+	return Position{
+		Board:   position.Board.Flipped(),
+		Player:  position.Player.Opponent(),
+		Variant: position.Variant,
+	}
+}
@@ -0,0 +1,51 @@
+package checkerscore
+
+import (
+	"github.com/couchbaselabs/go.assert"
+	"math/rand"
+	"testing"
+)
+
+func TestFlippedBoard(t *testing.T) {
+
+	board := NewStartingBoard()
+	assert.Equals(t, board.Flipped(), board)
+
+	position, _ := ParseFEN("B:W18,K32:B1,K14")
+	flipped := position.Flipped()
+	assert.Equals(t, flipped.FEN(), "W:WK19,32:BK1,15")
+	assert.Equals(t, flipped.Flipped(), position)
+
+	loc, _ := NewLocationFromSquareNumber(7)
+	assert.Equals(t, loc.Flipped().SquareNumber(), 26)
+	assert.Equals(t, BLACK_KING.SwapColor(), RED_KING)
+	assert.Equals(t, EMPTY.SwapColor(), EMPTY)
+
+}
+
+func TestLegalMovesCommuteWithFlip(t *testing.T) {
+
+	random := rand.New(rand.NewSource(7))
+	for _, variant := range []*Variant{ENGLISH_VARIANT, RUSSIAN_VARIANT, ITALIAN_VARIANT} {
+		for game := 0; game < 20; game++ {
+			position := variant.NewStartingPosition()
+			for ply := 0; ply < 120; ply++ {
+				moves := position.LegalMoves()
+				flippedMoves := []Move{}
+				for _, move := range moves {
+					flippedMoves = append(flippedMoves, move.Flipped())
+				}
+				flipped := position.Flipped()
+				assert.Equals(t, sortedNotations(flipped.LegalMoves()), sortedNotations(flippedMoves))
+				if len(moves) == 0 {
+					break
+				}
+
+				move := moves[random.Intn(len(moves))]
+				assert.Equals(t, flipped.ApplyMove(move.Flipped()), position.ApplyMove(move).Flipped())
+				position = position.ApplyMove(move)
+			}
+		}
+	}
+
+}
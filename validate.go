@@ -0,0 +1,89 @@
+package checkerscore
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// The maximum number of pieces each player can have on the board
+const maxPiecesPerPlayer = 12
+
+// A single rule violation found when validating a board
+type BoardViolation struct {
+	Location Location
+	Reason   string
+}
+
+// Returned by Board.Validate, lists every rule violation found.
+type BoardValidationError struct {
+	Violations []BoardViolation
+}
+
+func (violation BoardViolation) String() string {
+	loc := violation.Location
+	return fmt.Sprintf("(%d,%d): %v", loc.row, loc.col, violation.Reason)
+}
+
+func (err BoardValidationError) Error() string {
+	buffer := bytes.Buffer{}
+	buffer.WriteString("invalid board: ")
+	for i, violation := range err.Violations {
+		if i > 0 {
+			buffer.WriteString("; ")
+		}
+		buffer.WriteString(violation.String())
+	}
+	return buffer.String()
+}
+
+/*
+Check that the board could occur in a game, returning a
+BoardValidationError listing every violation found:
+
+  - pieces on light squares, which can never be reached
+  - men on the row where they would have been promoted to kings
+  - more than twelve pieces for either player (the extra pieces are
+    reported, scanning from the top left)
+
+*/
+func (board Board) Validate() error {
+	return board.ValidateForVariant(nil)
+}
+
+// Like Validate, but for a board of the given variant, whose playable
+// squares are the light ones of an English board when it is Mirrored
+func (board Board) ValidateForVariant(variant *Variant) error {
+
+	violations := []BoardViolation{}
+	addViolation := func(loc Location, reason string) {
+		violations = append(violations, BoardViolation{Location: loc, Reason: reason})
+	}
+
+	pieceCounts := map[Player]int{}
+	board.applyEachSquare(func(loc Location) {
+		piece := board.pieceAt(loc)
+		if piece == EMPTY {
+			return
+		}
+		player := piece.Owner()
+
+		if !variant.isPlayable(loc) {
+			addViolation(loc, fmt.Sprintf("%v on light square", piece))
+		}
+
+		if !piece.IsKing() && board.isOnOpponentsFirstRank(loc, player) {
+			addViolation(loc, fmt.Sprintf("%v on promotion row should be a king", piece))
+		}
+
+		pieceCounts[player] += 1
+		if pieceCounts[player] > maxPiecesPerPlayer {
+			addViolation(loc, fmt.Sprintf("%v exceeds %d pieces", piece, maxPiecesPerPlayer))
+		}
+	})
+
+	if len(violations) > 0 {
+		return BoardValidationError{Violations: violations}
+	}
+	return nil
+
+}
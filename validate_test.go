@@ -0,0 +1,83 @@
+package checkerscore
+
+import (
+	"github.com/couchbaselabs/go.assert"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+
+	assert.True(t, NewStartingBoard().Validate() == nil)
+	assert.True(t, NewEmptyBoard().Validate() == nil)
+
+	currentBoardStr := "" +
+		"|- - - - - - - o|" +
+		"|x - - - - - - -|" +
+		"|- - X - o - - -|" +
+		"|- - - - - - - -|" +
+		"|- - - - - - - -|" +
+		"|- - - - - - - -|" +
+		"|- - - - - - - -|" +
+		"|x - o - O - - -|"
+	board := NewBoard(currentBoardStr)
+	err := board.Validate()
+	assert.True(t, err != nil)
+
+	validationErr, ok := err.(BoardValidationError)
+	assert.True(t, ok)
+	violations := validationErr.Violations
+	assert.Equals(t, len(violations), 3)
+	assert.Equals(t, violations[0].Location, Location{row: 2, col: 2})
+	assert.Equals(t, violations[1].Location, Location{row: 2, col: 4})
+	assert.Equals(t, violations[2].Location, Location{row: 7, col: 2})
+
+	assert.Equals(t, violations[0].String(), "(2,2): X on light square")
+	assert.Equals(t, violations[2].Reason, "o on promotion row should be a king")
+
+	currentBoardStr = "" +
+		"|- - - - - - o -|" +
+		"|- - - - - - - -|" +
+		"|- - - - - - - -|" +
+		"|- - - - - - - -|" +
+		"|- - - - - - - -|" +
+		"|- - - - - - - -|" +
+		"|- - - - - - - -|" +
+		"|- - - - - - - -|"
+	board = NewBoard(currentBoardStr)
+	err = board.Validate()
+	assert.Equals(t, err.Error(), "invalid board: (0,6): o on light square")
+
+	currentBoardStr = "" +
+		"|- x - - - - - -|" +
+		"|- - - - - - - -|" +
+		"|- - - - - - - -|" +
+		"|- - - - - - - -|" +
+		"|- - - - - - - -|" +
+		"|- - - - - - - -|" +
+		"|- - - - - - - -|" +
+		"|o - - - - - - -|"
+	board = NewBoard(currentBoardStr)
+	err = board.Validate()
+	expected := "invalid board: " +
+		"(0,1): x on promotion row should be a king; " +
+		"(7,0): o on promotion row should be a king"
+	assert.Equals(t, err.Error(), expected)
+
+}
+
+func TestValidateTooManyPieces(t *testing.T) {
+
+	currentBoardStr := "" +
+		"|- o - o - o - o|" +
+		"|o - o - o - o -|" +
+		"|- o - o - o - o|" +
+		"|o - - - - - - -|" +
+		"|- - - - - - - -|" +
+		"|x - x - x - x -|" +
+		"|- x - x - x - x|" +
+		"|x - x - x - x -|"
+	board := NewBoard(currentBoardStr)
+	err := board.Validate()
+	assert.Equals(t, err.Error(), "invalid board: (3,0): o exceeds 12 pieces")
+
+}
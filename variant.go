@@ -0,0 +1,397 @@
+package checkerscore
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+/*
+The rules of a checkers variant played on an 8x8 board, consulted by
+Board.LegalMovesForVariant and Board.ApplyMoveForVariant.  The zero value
+of each rule is the English one, and a nil *Variant plays English
+checkers.  In every variant captured pieces stay on the board until the
+end of the move and can't be jumped twice.
+
+A variant's starting position is the StartingPosition with the same name.
+*/
+type Variant struct {
+	Name string
+
+	// men can capture backwards as well as forwards
+	MenCaptureBackwards bool
+
+	// kings move and capture any distance along a diagonal, landing on
+	// any empty square beyond the captured piece
+	FlyingKings bool
+
+	// the capture taking the most pieces must be played
+	MaximumCapture bool
+
+	// men can't capture kings
+	MenCannotCaptureKings bool
+
+	// a man reaching the last row during a capture is promoted and keeps
+	// capturing as a king.  Otherwise it keeps capturing as a man, and is
+	// only promoted if the move ends on the last row.
+	PromoteMidCapture bool
+
+	// if not nil, applied to the captures available after MaximumCapture,
+	// eg to give priority to captures by kings
+	CaptureFilter func(board Board, captures []Move) []Move
+
+	// the player left without a move wins, rather than loses
+	Giveaway bool
+
+	// the board is the mirror image of the English one, with a light
+	// square in each player's left-hand corner, so the playable squares
+	// are those where row+col is even.  Squares are numbered as in
+	// English checkers, from 1 on black's side reading left to right (as
+	// red sees the board), as in Italian notation.
+	Mirrored bool
+
+	// the squares of a Mirrored board are numbered from 1 in red's
+	// (white's) right-hand corner instead, reading right to left along
+	// each row towards black's side, as in Spanish notation
+	NumberedFromRed bool
+}
+
+var ENGLISH_VARIANT = &Variant{Name: "english"}
+
+var variants = map[string]*Variant{}
+
+func init() {
+	registerVariant(ENGLISH_VARIANT)
+}
+
+func registerVariant(variant *Variant) {
+	variants[variant.Name] = variant
+}
+
+func VariantNamed(name string) (*Variant, error) {
+	variant, ok := variants[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown variant: %v", name)
+	}
+	return variant, nil
+}
+
+// The names of all the available variants, sorted.
+func VariantNames() []string {
+	names := []string{}
+	for name := range variants {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// The variant's starting position
+func (variant *Variant) NewStartingPosition() Position {
+	start, err := StartingPositionNamed(variant.Name)
+	if err != nil {
+		panic(err)
+	}
+	return Position{Board: start.Board, Player: start.FirstPlayer, Variant: variant}
+}
+
+// The winner when player has no legal moves
+func (variant *Variant) winnerWithoutMoves(player Player) GameResult {
+	winner := player.Opponent()
+	if variant != nil && variant.Giveaway {
+		winner = player
+	}
+	if winner == BLACK_PLAYER {
+		return BLACK_WINS
+	}
+	return RED_WINS
+}
+
+// The variant's rules, with English checkers for a nil variant
+func (variant *Variant) rules() *Variant {
+	if variant == nil {
+		return ENGLISH_VARIANT
+	}
+	return variant
+}
+
+// Whether pieces can stand on loc, a dark square of the variant's board
+func (variant *Variant) isPlayable(loc Location) bool {
+	if variant.rules().Mirrored {
+		return (loc.row+loc.col)%2 == 0
+	}
+	return loc.isDarkSquare()
+}
+
+// Returns the square number (1-32) of this location in the variant's
+// notation, or 0 if the location is not a playable square.
+func (variant *Variant) SquareNumber(loc Location) int {
+	if !variant.rules().Mirrored {
+		return loc.SquareNumber()
+	}
+	if loc.isOffBoard() || !variant.isPlayable(loc) {
+		return 0
+	}
+	if variant.rules().NumberedFromRed {
+		return (7-loc.row)*4 + (7-loc.col)/2 + 1
+	}
+	return loc.row*4 + loc.col/2 + 1
+}
+
+// Returns the location of the given square number (1-32) in the
+// variant's notation
+func (variant *Variant) LocationOfSquare(square int) (Location, error) {
+	if !variant.rules().Mirrored {
+		return NewLocationFromSquareNumber(square)
+	}
+	if square < 1 || square > 32 {
+		return Location{}, fmt.Errorf("invalid square number: %d", square)
+	}
+	index := square - 1
+	if variant.rules().NumberedFromRed {
+		row := 7 - index/4
+		col := 7 - (index%4)*2
+		if row%2 == 0 {
+			col -= 1
+		}
+		return Location{row: row, col: col}, nil
+	}
+	row := index / 4
+	col := (index % 4) * 2
+	if row%2 == 1 {
+		col += 1
+	}
+	return Location{row: row, col: col}, nil
+}
+
+// Serialize a move in the variant's notation, as for Move.Notation
+func (variant *Variant) MoveNotation(move Move) string {
+	separator := "-"
+	if move.IsJump() {
+		separator = "x"
+	}
+	buffer := bytes.Buffer{}
+	for i, loc := range move.path() {
+		if i > 0 {
+			buffer.WriteString(separator)
+		}
+		buffer.WriteString(strconv.Itoa(variant.SquareNumber(loc)))
+	}
+	return buffer.String()
+}
+
+/*
+The squares of a board of any size, as read by the move generator.  The
+generator only calls pieceAt for locations on the board.
+*/
+type squares interface {
+	size() int
+	pieceAt(loc Location) Piece
+
+	// a copy of the board with loc emptied
+	without(loc Location) squares
+}
+
+// The legal moves for player: the captures if there are any, only those
+// taking the most pieces under the maximum capture rule and passing the
+// capture filter, and otherwise the ordinary moves.
+func (variant *Variant) legalMoves(board squares, player Player) []Move {
+
+	captures := []Move{}
+	eachSquare(board, func(loc Location) {
+		if board.pieceAt(loc).OwnedBy(player) {
+			captures = append(captures, variant.capturesFrom(board, loc)...)
+		}
+	})
+	if len(captures) > 0 {
+		if variant.MaximumCapture {
+			captures = mostCaptures(captures)
+		}
+		if variant.CaptureFilter != nil {
+			// only variants played on a Board have capture filters
+			captures = variant.CaptureFilter(board.(Board), captures)
+		}
+		return captures
+	}
+
+	moves := []Move{}
+	eachSquare(board, func(loc Location) {
+		if board.pieceAt(loc).OwnedBy(player) {
+			moves = append(moves, variant.movesFrom(board, loc)...)
+		}
+	})
+	return moves
+
+}
+
+// The non capturing moves of the piece on from
+func (variant *Variant) movesFrom(board squares, from Location) []Move {
+
+	moves := []Move{}
+	piece := board.pieceAt(from)
+	for _, direction := range diagonals {
+		for to := from.step(direction); variant.canMove(board, piece.Owner(), from, to); to = to.step(direction) {
+			moves = append(moves, NewMoveFromTo(from, to))
+			if !piece.IsKing() || !variant.FlyingKings {
+				break
+			}
+		}
+	}
+	return moves
+
+}
+
+// Every complete capture sequence starting from loc, as moves made of
+// single jump submoves
+func (variant *Variant) capturesFrom(board squares, from Location) []Move {
+
+	piece := board.pieceAt(from)
+	player := piece.Owner()
+
+	// the moving piece leaves its square, which it may pass over again.
+	// Captured pieces stay on the board until the end of the move, but
+	// can't be jumped twice.
+	board = board.without(from)
+	captured := map[Location]bool{}
+	sequences := []Move{}
+
+	var extend func(loc Location, piece Piece, jumps []Move)
+	extend = func(loc Location, piece Piece, jumps []Move) {
+		extended := false
+		for _, jump := range variant.jumpsFrom(board, player, loc, piece) {
+			if captured[jump.over] {
+				continue
+			}
+			extended = true
+			next := piece
+			if variant.PromoteMidCapture && jump.to.row == promotionRow(player, board.size()) {
+				next = piece.King()
+			}
+			captured[jump.over] = true
+			extend(jump.to, next, append(append([]Move{}, jumps...), jump))
+			captured[jump.over] = false
+		}
+		if !extended && len(jumps) > 0 {
+			sequences = append(sequences, NewMove(jumps))
+		}
+	}
+	extend(from, piece, []Move{})
+	return sequences
+
+}
+
+// The single jumps that piece, owned by player, could make from loc.
+// A man reaching the last row can only carry on capturing if it captures
+// backwards, so in English checkers the move ends there.
+func (variant *Variant) jumpsFrom(board squares, player Player, loc Location, piece Piece) []Move {
+
+	jumps := []Move{}
+	flying := piece.IsKing() && variant.FlyingKings
+	for _, direction := range diagonals {
+		over := loc.step(direction)
+		if flying {
+			for !over.isOffBoardOfSize(board.size()) && board.pieceAt(over) == EMPTY {
+				over = over.step(direction)
+			}
+		}
+		for to := over.step(direction); variant.canJump(board, player, piece, loc, over, to); to = to.step(direction) {
+			jumps = append(jumps, Move{from: loc, over: over, to: to})
+			if !flying {
+				break
+			}
+		}
+	}
+	return jumps
+
+}
+
+// Whether player's piece on start can move to the empty square dest
+func (variant *Variant) canMove(board squares, player Player, start, dest Location) bool {
+
+	if dest.isOffBoardOfSize(board.size()) || board.pieceAt(dest) != EMPTY {
+		return false
+	}
+	return board.pieceAt(start).IsKing() || dest.row-start.row == forwardRow(player)
+
+}
+
+// Whether player's piece, standing on start, can capture the piece on
+// over and land on dest
+func (variant *Variant) canJump(board squares, player Player, piece Piece, start, over, dest Location) bool {
+
+	if dest.isOffBoardOfSize(board.size()) || board.pieceAt(dest) != EMPTY {
+		return false
+	}
+	if !piece.IsKing() && !variant.MenCaptureBackwards && (dest.row-start.row)*forwardRow(player) < 0 {
+		return false
+	}
+	captured := board.pieceAt(over)
+	if !captured.OwnedBy(player.Opponent()) {
+		return false
+	}
+	return piece.IsKing() || !captured.IsKing() || !variant.MenCannotCaptureKings
+
+}
+
+// The captures taking the most pieces
+func mostCaptures(captures []Move) []Move {
+	most := 0
+	for _, capture := range captures {
+		if len(capture.Captured()) > most {
+			most = len(capture.Captured())
+		}
+	}
+	return filterMoves(captures, func(move Move) bool {
+		return len(move.Captured()) == most
+	})
+}
+
+// The piece player's piece becomes after making move: promoted if it ends
+// on the last row, or passes over it mid capture when the variant
+// promotes there.
+func (variant *Variant) movedPiece(board squares, player Player, move Move) Piece {
+
+	piece := board.pieceAt(move.from)
+	lastRow := promotionRow(player, board.size())
+	promoted := move.to.row == lastRow
+	if variant.PromoteMidCapture {
+		for _, loc := range move.path() {
+			promoted = promoted || loc.row == lastRow
+		}
+	}
+	if promoted {
+		return piece.King()
+	}
+	return piece
+
+}
+
+func eachSquare(board squares, f func(loc Location)) {
+	for row := 0; row < board.size(); row++ {
+		for col := 0; col < board.size(); col++ {
+			f(Location{row: row, col: col})
+		}
+	}
+}
+
+// The legal moves for player under the variant's rules, English checkers
+// for a nil variant
+func (board Board) LegalMovesForVariant(player Player, variant *Variant) []Move {
+	return variant.rules().legalMoves(board, player)
+}
+
+// Apply a legal move, removing any captured pieces and promoting the
+// piece if it ends on the last row, or passes over it mid capture when
+// the variant promotes there.
+func (board Board) ApplyMoveForVariant(player Player, move Move, variant *Variant) Board {
+
+	piece := variant.rules().movedPiece(board, player, move)
+	board[move.from.row][move.from.col] = EMPTY
+	for _, loc := range move.Captured() {
+		board[loc.row][loc.col] = EMPTY
+	}
+	board[move.to.row][move.to.col] = piece
+	return board
+
+}
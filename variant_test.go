@@ -0,0 +1,131 @@
+package checkerscore
+
+import (
+	"github.com/couchbaselabs/go.assert"
+	"sort"
+	"testing"
+)
+
+func sortedNotations(moves []Move) []string {
+	notations := []string{}
+	for _, move := range moves {
+		notations = append(notations, move.Notation())
+	}
+	sort.Strings(notations)
+	return notations
+}
+
+// The legal moves in the position's notation, sorted
+func sortedPositionNotations(position Position) []string {
+	notations := []string{}
+	for _, move := range position.LegalMoves() {
+		notations = append(notations, position.MoveNotation(move))
+	}
+	sort.Strings(notations)
+	return notations
+}
+
+func TestVariantPerft(t *testing.T) {
+	expected := []int{7, 49, 302, 1469, 7361}
+	position := NewStartingPosition()
+	position.Variant = ENGLISH_VARIANT
+	for i, count := range expected {
+		assert.Equals(t, position.Perft(i+1), count)
+	}
+}
+
+func TestVariantFlyingKings(t *testing.T) {
+
+	flying := &Variant{Name: "flying", FlyingKings: true}
+	position, _ := ParseFEN("W:WK29:B18")
+	board := position.Board
+
+	assert.Equals(t, sortedNotations(board.LegalMoves(RED_PLAYER)), []string{"29-25"})
+	assert.Equals(t, sortedNotations(board.LegalMovesForVariant(RED_PLAYER, flying)), []string{"29x11", "29x15", "29x4", "29x8"})
+
+	move := board.LegalMovesForVariant(RED_PLAYER, flying)[0]
+	after := board.ApplyMoveForVariant(RED_PLAYER, move, flying)
+	assert.Equals(t, after.PieceAt(move.To()), RED_KING)
+	assert.Equals(t, after.PieceAt(move.Captured()[0]), EMPTY)
+
+	board[4][3] = EMPTY
+	assert.Equals(t, len(board.LegalMovesForVariant(RED_PLAYER, flying)), 7)
+
+}
+
+func TestVariantMenCaptureBackwards(t *testing.T) {
+
+	position, _ := ParseFEN("W:W17:B22,14,7")
+	board := position.Board
+
+	backwards := &Variant{Name: "backwards", MenCaptureBackwards: true}
+	assert.Equals(t, sortedNotations(board.LegalMoves(RED_PLAYER)), []string{"17x10x3"})
+	assert.Equals(t, sortedNotations(board.LegalMovesForVariant(RED_PLAYER, backwards)), []string{"17x10x3", "17x26"})
+
+	backwards.MaximumCapture = true
+	assert.Equals(t, sortedNotations(board.LegalMovesForVariant(RED_PLAYER, backwards)), []string{"17x10x3"})
+
+	backwards.CaptureFilter = func(board Board, captures []Move) []Move {
+		return []Move{}
+	}
+	assert.Equals(t, len(board.LegalMovesForVariant(RED_PLAYER, backwards)), 0)
+
+}
+
+func TestVariantPromoteMidCapture(t *testing.T) {
+
+	position, _ := ParseFEN("W:W11:B7,6")
+	board := position.Board
+
+	// in english checkers the move ends on promotion
+	moves := board.LegalMoves(RED_PLAYER)
+	assert.Equals(t, sortedNotations(moves), []string{"11x2"})
+
+	// otherwise the man carries on capturing, and is only promoted if
+	// the variant promotes mid capture
+	backwards := &Variant{Name: "backwards", MenCaptureBackwards: true}
+	moves = board.LegalMovesForVariant(RED_PLAYER, backwards)
+	assert.Equals(t, sortedNotations(moves), []string{"11x2x9"})
+	assert.Equals(t, board.ApplyMoveForVariant(RED_PLAYER, moves[0], backwards).PieceAt(moves[0].To()), RED)
+
+	promoting := &Variant{Name: "promoting", MenCaptureBackwards: true, PromoteMidCapture: true}
+	moves = board.LegalMovesForVariant(RED_PLAYER, promoting)
+	assert.Equals(t, sortedNotations(moves), []string{"11x2x9"})
+	assert.Equals(t, board.ApplyMoveForVariant(RED_PLAYER, moves[0], promoting).PieceAt(moves[0].To()), RED_KING)
+
+}
+
+func TestVariantNamed(t *testing.T) {
+	variant, err := VariantNamed("english")
+	assert.True(t, err == nil)
+	assert.Equals(t, variant, ENGLISH_VARIANT)
+	assert.Equals(t, variant.NewStartingPosition().Board, NewStartingBoard())
+	_, err = VariantNamed("klingon")
+	assert.True(t, err != nil)
+}
+
+func TestVariantFlyingKingJumps(t *testing.T) {
+
+	flying := &Variant{Name: "flying", FlyingKings: true}
+	position, _ := ParseFEN("W:WK29:B3")
+	position.Variant = flying
+
+	// a slide of two squares captures nothing
+	move, err := position.ParseMove("29-22")
+	assert.True(t, err == nil)
+	assert.False(t, move.IsJump())
+	assert.Equals(t, move.Notation(), "29-22")
+	assert.Equals(t, len(move.Captured()), 0)
+
+	// while a capture from a distance is a jump on every leg
+	position, _ = ParseFEN("W:WK29:B18")
+	position.Variant = flying
+	move, err = position.ParseMove("29x15")
+	assert.True(t, err == nil)
+	assert.Equals(t, move.Captured(), []Location{{row: 4, col: 3}})
+	for _, submove := range move.Submoves() {
+		assert.True(t, submove.IsJump())
+		assert.Equals(t, len(submove.Captured()), 1)
+	}
+
+}
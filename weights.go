@@ -0,0 +1,154 @@
+package checkerscore
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+/*
+The weights of a linear evaluation function, which scores a board as the
+sum over the features (see EvalFeatures) of the weight times the
+difference between the player's and the opponent's counts.
+
+Weights are stored in text files with one "name value" pair per line, in
+the order of EvalWeightNames, eg:
+
+	man 1.0
+	king 1.3
+	# comments and blank lines are ignored
+*/
+type EvalWeights struct {
+	Man         float64 // each man
+	King        float64 // each king
+	BackRank    float64 // each man on its own back row, keeping out kings
+	Center      float64 // each piece on the eight central squares, 10-11, 14-15, 18-19 and 22-23
+	Advancement float64 // each row a man has advanced
+}
+
+var evalWeightNames = []string{"man", "king", "backrank", "center", "advancement"}
+
+// The weights used by DefaultEvaluationFunction, the piece values from
+// Piece.WeightedValue.
+func DefaultEvalWeights() EvalWeights {
+	return EvalWeights{Man: BLACK.WeightedValue(), King: BLACK_KING.WeightedValue()}
+}
+
+// The names of the weights, in the order used by Vector and EvalFeatures
+func EvalWeightNames() []string {
+	return append([]string{}, evalWeightNames...)
+}
+
+func (weights EvalWeights) Vector() []float64 {
+	return []float64{weights.Man, weights.King, weights.BackRank, weights.Center, weights.Advancement}
+}
+
+func NewEvalWeights(vector []float64) (EvalWeights, error) {
+	if len(vector) != len(evalWeightNames) {
+		return EvalWeights{}, fmt.Errorf("expected %d weights, got %d", len(evalWeightNames), len(vector))
+	}
+	return EvalWeights{
+		Man:         vector[0],
+		King:        vector[1],
+		BackRank:    vector[2],
+		Center:      vector[3],
+		Advancement: vector[4],
+	}, nil
+}
+
+func (weights EvalWeights) EvaluationFunction() EvaluationFunction {
+	vector := weights.Vector()
+	evalFunc := func(player Player, board Board) float64 {
+		score := 0.0
+		for i, feature := range board.EvalFeatures(player) {
+			score += vector[i] * feature
+		}
+		return score
+	}
+	return evalFunc
+}
+
+// The difference between player's and the opponent's count of each
+// feature, in the order of EvalWeightNames
+func (board Board) EvalFeatures(player Player) []float64 {
+
+	features := make([]float64, len(evalWeightNames))
+	board.applyEachSquare(func(loc Location) {
+		piece := board.pieceAt(loc)
+		if piece == EMPTY {
+			return
+		}
+		owner := piece.Owner()
+		sign := 1.0
+		if owner != player {
+			sign = -1.0
+		}
+
+		if piece.IsKing() {
+			features[1] += sign
+		} else {
+			features[0] += sign
+			if board.isOnOpponentsFirstRank(loc, owner.Opponent()) {
+				features[2] += sign
+			}
+			advanced := loc.row
+			if owner == RED_PLAYER {
+				advanced = 7 - loc.row
+			}
+			features[4] += sign * float64(advanced)
+		}
+		if loc.row >= 2 && loc.row <= 5 && loc.col >= 2 && loc.col <= 5 {
+			features[3] += sign
+		}
+	})
+	return features
+
+}
+
+func ReadEvalWeights(reader io.Reader) (EvalWeights, error) {
+
+	vector := DefaultEvalWeights().Vector()
+	scanner := bufio.NewScanner(reader)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber += 1
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return EvalWeights{}, fmt.Errorf("line %d: expected name and value", lineNumber)
+		}
+		index := -1
+		for i, name := range evalWeightNames {
+			if name == fields[0] {
+				index = i
+			}
+		}
+		if index < 0 {
+			return EvalWeights{}, fmt.Errorf("line %d: unknown weight %q", lineNumber, fields[0])
+		}
+		value, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return EvalWeights{}, fmt.Errorf("line %d: invalid value %q", lineNumber, fields[1])
+		}
+		vector[index] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return EvalWeights{}, err
+	}
+	return NewEvalWeights(vector)
+
+}
+
+func (weights EvalWeights) Write(writer io.Writer) error {
+	for i, value := range weights.Vector() {
+		if _, err := fmt.Fprintf(writer, "%v %v\n", evalWeightNames[i], strconv.FormatFloat(value, 'g', -1, 64)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
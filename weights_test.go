@@ -0,0 +1,61 @@
+package checkerscore
+
+import (
+	"bytes"
+	"github.com/couchbaselabs/go.assert"
+	"strings"
+	"testing"
+)
+
+func TestEvalFeatures(t *testing.T) {
+
+	assert.Equals(t, NewStartingBoard().EvalFeatures(BLACK_PLAYER), []float64{0, 0, 0, 0, 0})
+
+	position, _ := ParseFEN("B:WK10,29,30,23:B1,15")
+	features := position.Board.EvalFeatures(BLACK_PLAYER)
+	assert.Equals(t, features, []float64{
+		2 - 3, // men
+		0 - 1, // kings
+		1 - 2, // back rank men on 1, and 29 and 30
+		1 - 2, // 15, and 10 and 23
+		3 - 2, // 15 is three rows down, 23 two rows up
+	})
+	assert.Equals(t, position.Board.EvalFeatures(RED_PLAYER)[0], 1.0)
+
+}
+
+func TestEvalWeights(t *testing.T) {
+
+	// the default weights match the default evaluation function
+	position, _ := ParseFEN("B:WK10,29,30,23:B1,15")
+	defaultEval := DefaultEvalWeights().EvaluationFunction()
+	assert.Equals(t, defaultEval(BLACK_PLAYER, position.Board), DefaultEvaluationFunction()(BLACK_PLAYER, position.Board))
+
+	weights := EvalWeights{Man: 1, King: 2, BackRank: 0.5, Center: 0.25, Advancement: 0.125}
+	assert.Equals(t, weights.EvaluationFunction()(BLACK_PLAYER, position.Board), -1-2-0.5-0.25+0.125)
+
+	_, err := NewEvalWeights([]float64{1})
+	assert.True(t, err != nil)
+
+}
+
+func TestEvalWeightsFile(t *testing.T) {
+
+	weights := EvalWeights{Man: 1, King: 1.45, BackRank: 0.1, Center: 0.05, Advancement: -0.02}
+	buffer := bytes.Buffer{}
+	assert.True(t, weights.Write(&buffer) == nil)
+	assert.Equals(t, buffer.String(), "man 1\nking 1.45\nbackrank 0.1\ncenter 0.05\nadvancement -0.02\n")
+
+	read, err := ReadEvalWeights(&buffer)
+	assert.True(t, err == nil)
+	assert.Equals(t, read, weights)
+
+	// missing weights keep their default values
+	read, err = ReadEvalWeights(strings.NewReader("# tuned\n\ncenter 0.2\n"))
+	assert.True(t, err == nil)
+	assert.Equals(t, read, EvalWeights{Man: 1, King: 1.3, Center: 0.2})
+
+	_, err = ReadEvalWeights(strings.NewReader("queen 9\n"))
+	assert.Equals(t, err.Error(), `line 1: unknown weight "queen"`)
+
+}